@@ -3,34 +3,68 @@ package main
 import (
 	"context"
 	"flag"
-	"path/filepath"
+	"fmt"
+	"os"
 
 	"github.com/inviewteam/fenrir.executor/internal/application"
+	"github.com/inviewteam/fenrir.executor/internal/config"
 	server "github.com/inviewteam/fenrir.executor/internal/infrastructure/http"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/logging"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/tracing"
 )
 
 func main() {
 	ctx := context.Background()
-	var kubeconfig *string
-	if home := homedir.HomeDir(); home != "" {
-		kubeconfig = flag.String("kubeconfig", filepath.Join(home, ".kube", "config"), "(optional) absolute path to the kubeconfig file")
-	} else {
-		kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
-	}
+	configFile := flag.String("config", os.Getenv(config.EnvPrefix+"_CONFIG"), "(optional) path to the YAML configuration file")
+	kubeconfig := flag.String("kubeconfig", "", "(optional) absolute path to the kubeconfig file, overrides kubernetes.kubeconfig")
+	kubeContext := flag.String("context", "", "(optional) kubeconfig context to use, overrides kubernetes.context")
+	namespace := flag.String("namespace", "", "(optional) default namespace, overrides kubernetes.namespace")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration and exit")
 	flag.Parse()
 
-	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if *kubeconfig != "" {
+		cfg.Kubernetes.Kubeconfig = *kubeconfig
+	}
+	if *kubeContext != "" {
+		cfg.Kubernetes.Context = *kubeContext
+	}
+	if *namespace != "" {
+		cfg.Kubernetes.Namespace = *namespace
+	}
+	if *printConfig {
+		out, err := cfg.YAML()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(out)
+		return
+	}
+
+	if err := logging.Setup(cfg.Log.Level); err != nil {
+		panic(err)
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
 	if err != nil {
 		panic(err)
 	}
+	defer shutdownTracing(context.Background())
 
-	app, err := application.New(ctx, config)
+	app, err := application.New(ctx, cfg.Kubernetes, cfg.Application)
 	if err != nil {
 		panic(err)
 	}
 
-	srv := server.NewServer(app)
-	srv.Start(ctx)
+	srv := server.NewServer(app, cfg.Server)
+	if err := srv.Start(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		shutdownTracing(context.Background())
+		os.Exit(1)
+	}
 }
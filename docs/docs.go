@@ -16,25 +16,18 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/kubernetes/{namespace}/deployments/{deployment_name}": {
+        "/approvals/{approval_id}": {
             "get": {
-                "description": "Get Deployment Information by name and namespace",
+                "description": "Get the state of an action waiting for approval",
                 "tags": [
-                    "Deployments"
+                    "Approvals"
                 ],
-                "summary": "Get Deployment Information",
+                "summary": "Get Approval",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Namespace name",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment name",
-                        "name": "deployment_name",
+                        "description": "Approval ID",
+                        "name": "approval_id",
                         "in": "path",
                         "required": true
                     }
@@ -43,117 +36,182 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.Deployment"
+                            "$ref": "#/definitions/views.Approval"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Scale Deployment",
+            }
+        },
+        "/approvals/{approval_id}/approve": {
+            "post": {
+                "description": "Approve and execute an action waiting for approval. The approver must differ from the requester.",
                 "tags": [
-                    "Deployments"
+                    "Approvals"
                 ],
-                "summary": "Scale Deployment",
+                "summary": "Approve Action",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Name of namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Name of Deployment",
-                        "name": "deployment_name",
+                        "description": "Approval ID",
+                        "name": "approval_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Amount of Replicas",
-                        "name": "replicas",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "504": {
+                        "description": "The approved action did not complete in time",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
                     }
                 }
             }
         },
-        "/kubernetes/{namespace}/deployments/{deployment_name}/describe": {
-            "get": {
-                "description": "Describe Deployment",
+        "/approvals/{approval_id}/reject": {
+            "post": {
+                "description": "Discard an action waiting for approval. The rejecter must differ from the requester.",
                 "tags": [
-                    "Deployments"
+                    "Approvals"
                 ],
-                "summary": "Describe Deployment",
+                "summary": "Reject Action",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Name of namespace",
-                        "name": "namespace",
+                        "description": "Approval ID",
+                        "name": "approval_id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.Approval"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Name of Deployment",
-                        "name": "deployment_name",
-                        "in": "path",
-                        "required": true
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
                     }
+                }
+            }
+        },
+        "/clusters": {
+            "get": {
+                "description": "List the clusters served by the executor and whether their API server is reachable.\nRoutes under /clusters/{cluster}/ act on the named cluster, the other routes on the default one.",
+                "tags": [
+                    "Clusters"
                 ],
+                "summary": "List Clusters",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/views.Cluster"
+                            }
                         }
                     }
                 }
             }
         },
-        "/kubernetes/{namespace}/deployments/{deployment_name}/rollback": {
-            "post": {
-                "description": "Rollback a deployment to the previous version",
+        "/kubernetes/namespaces": {
+            "get": {
+                "description": "List namespaces, one page at a time",
                 "tags": [
-                    "Deployments"
+                    "Namespaces"
                 ],
-                "summary": "Rollback Deployment",
+                "summary": "List Namespaces",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Namespace name",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
+                        "description": "Kubernetes label selector, e.g. app=payments",
+                        "name": "labelSelector",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Deployment name",
-                        "name": "deployment_name",
-                        "in": "path",
-                        "required": true
+                        "description": "Kubernetes field selector, e.g. status.phase=Active",
+                        "name": "fieldSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items in the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue token of the previous page",
+                        "name": "continue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the page: name or age, prefixed with - for descending",
+                        "name": "sortBy",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.NamespaceList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad selector or query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "The continue token expired",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
                     }
                 }
             }
         },
-        "/kubernetes/{namespace}/pods": {
+        "/kubernetes/{namespace}/deployments": {
             "get": {
-                "description": "Lists Pods by Deployment",
+                "description": "List deployments of the namespace, one page at a time\nServed by the informer cache when enabled for the namespace and neither fieldSelector nor pagination is used",
                 "tags": [
-                    "Pods"
+                    "Deployments"
                 ],
-                "summary": "Lists Pods by Deployment",
+                "summary": "List Deployments",
                 "parameters": [
                     {
                         "type": "string",
@@ -164,41 +222,82 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Name of deployment",
-                        "name": "deployment",
-                        "in": "query",
-                        "required": true
+                        "description": "Kubernetes label selector, e.g. app=payments",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes field selector, e.g. metadata.name=api",
+                        "name": "fieldSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items in the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue token of the previous page",
+                        "name": "continue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the page: name or age, prefixed with - for descending",
+                        "name": "sortBy",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.DeploymentPods"
+                            "$ref": "#/definitions/views.DeploymentList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad selector or query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "The continue token expired",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
                         }
                     }
                 }
             }
         },
-        "/kubernetes/{namespace}/pods/{pod_name}": {
+        "/kubernetes/{namespace}/deployments/{deployment_name}": {
             "get": {
-                "description": "Get Pod Information",
+                "description": "Get Deployment Information by name and namespace",
                 "tags": [
-                    "Pods"
+                    "Deployments"
                 ],
-                "summary": "Get Pod Information",
+                "summary": "Get Deployment Information",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Name of namespace",
+                        "description": "Namespace name",
                         "name": "namespace",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Name of pod",
-                        "name": "pod_name",
+                        "description": "Deployment name",
+                        "name": "deployment_name",
                         "in": "path",
                         "required": true
                     }
@@ -207,17 +306,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.Pod"
+                            "$ref": "#/definitions/views.Deployment"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "restart pod",
+            "put": {
+                "description": "Scale Deployment",
                 "tags": [
-                    "Pods"
+                    "Deployments"
                 ],
-                "summary": "Restart Pod",
+                "summary": "Scale Deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -228,26 +333,84 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Name of pod",
-                        "name": "pod_name",
+                        "description": "Name of Deployment",
+                        "name": "deployment_name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of Replicas",
+                        "name": "replicas",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum time to wait for the replicas to be ready, e.g. 90s; capped by the configured wait timeout",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Scale even though a horizontal pod autoscaler owns the replicas or a PodDisruptionBudget requires more healthy pods, with a warning",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the deployment, a horizontal pod autoscaler owns its replicas, or a PodDisruptionBudget requires more healthy pods",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "The deployment was not scaled in time",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
                     }
                 }
             }
         },
-        "/kubernetes/{namespace}/pods/{pod_name}/describe": {
+        "/kubernetes/{namespace}/deployments/{deployment_name}/describe": {
             "get": {
-                "description": "Describe Pod",
+                "description": "Describe Deployment",
                 "tags": [
-                    "Pods"
+                    "Deployments"
                 ],
-                "summary": "Describe Pod",
+                "summary": "Describe Deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -258,8 +421,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Name of pod",
-                        "name": "pod_name",
+                        "description": "Name of Deployment",
+                        "name": "deployment_name",
                         "in": "path",
                         "required": true
                     }
@@ -270,17 +433,23 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
                     }
                 }
             }
         },
-        "/kubernetes/{namespace}/pods/{pod_name}/logs": {
+        "/kubernetes/{namespace}/deployments/{deployment_name}/disruptionbudgets": {
             "get": {
-                "description": "Get Pod Logs",
+                "description": "List the PodDisruptionBudgets covering the pods of a deployment",
                 "tags": [
-                    "Pods"
+                    "Deployments"
                 ],
-                "summary": "Get Pod Logs",
+                "summary": "List Pod Disruption Budgets of Deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -291,37 +460,1443 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Name of pod",
-                        "name": "pod_name",
+                        "description": "Name of Deployment",
+                        "name": "deployment_name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Name of container",
-                        "name": "container",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of lines to show",
-                        "name": "tail",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/views.DisruptionBudgetList"
                         }
-                    }
-                }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/deployments/{deployment_name}/restart": {
+            "put": {
+                "description": "Restart the pods of a deployment in waves, waiting for each wave to be replaced by ready pods, and report the outcome per pod",
+                "tags": [
+                    "Deployments"
+                ],
+                "summary": "Rolling Restart Deployment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace name",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment name",
+                        "name": "deployment_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Number of pods restarted at once",
+                        "name": "waveSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of pods allowed to fail before the remaining waves are cancelled",
+                        "name": "maxFailures",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum time to wait for each pod to be replaced by a ready pod, e.g. 90s; capped by the configured wait timeout",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "evict",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "evict honours PodDisruptionBudgets, delete bypasses them; the configured restart mode when absent",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Termination grace period of the pods, e.g. 30s; their own when absent",
+                        "name": "gracePeriod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Restart pods even though a PodDisruptionBudget allows no disruption, with a warning; evictions are still refused by the API server, unlike deletions",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the deployment, or too many pods failed, e.g. because a PodDisruptionBudget allows no disruption; pods reports the outcome per pod",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/deployments/{deployment_name}/rollback": {
+            "post": {
+                "description": "Rollback a deployment to the previous version",
+                "tags": [
+                    "Deployments"
+                ],
+                "summary": "Rollback Deployment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Namespace name",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment name",
+                        "name": "deployment_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the deployment",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/horizontalpodautoscalers/{hpa_name}": {
+            "get": {
+                "description": "Get the limits, status and metrics of a horizontal pod autoscaler",
+                "tags": [
+                    "Autoscalers"
+                ],
+                "summary": "Get Horizontal Pod Autoscaler",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of horizontal pod autoscaler",
+                        "name": "hpa_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.HorizontalPodAutoscaler"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the replica range of a horizontal pod autoscaler, which is how to scale a workload it owns",
+                "tags": [
+                    "Autoscalers"
+                ],
+                "summary": "Set Horizontal Pod Autoscaler Limits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of horizontal pod autoscaler",
+                        "name": "hpa_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum number of replicas; unchanged when absent",
+                        "name": "minReplicas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of replicas; unchanged when absent",
+                        "name": "maxReplicas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the autoscaler",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/pods": {
+            "get": {
+                "description": "List pods of the namespace, one page at a time\nServed by the informer cache when enabled for the namespace and neither fieldSelector nor pagination is used",
+                "tags": [
+                    "Pods"
+                ],
+                "summary": "List Pods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes label selector, e.g. app=payments",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes field selector, e.g. status.phase=Running",
+                        "name": "fieldSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items in the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue token of the previous page",
+                        "name": "continue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the page: name or age, prefixed with - for descending",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List the pods of the deployment as views.DeploymentPods instead, with their ReplicaSet revision; other list parameters are ignored",
+                        "name": "deployment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With deployment, include container usage fetched in one metrics call",
+                        "name": "metrics",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.PodList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad selector or query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "The continue token expired",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/pods/{pod_name}": {
+            "get": {
+                "description": "Get Pod Information",
+                "tags": [
+                    "Pods"
+                ],
+                "summary": "Get Pod Information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of pod",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.Pod"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a pod and wait until its controller replaced it with a ready pod, reported as the replacement",
+                "tags": [
+                    "Pods"
+                ],
+                "summary": "Restart Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of pod",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum time to wait for the pod to be replaced by a ready pod, e.g. 90s; capped by the configured wait timeout",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "evict",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "evict honours PodDisruptionBudgets, delete bypasses them; the configured restart mode when absent",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Termination grace period of the pod, e.g. 30s; its own when absent",
+                        "name": "gracePeriod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Restart even though a PodDisruptionBudget allows no disruption, with a warning; evictions are still refused by the API server, unlike deletions",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the pod, a PodDisruptionBudget allows no disruption, or the replacement pod failed",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Eviction blocked by a PodDisruptionBudget; retry after the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "The pod was not replaced in time",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/pods/{pod_name}/describe": {
+            "get": {
+                "description": "Describe Pod",
+                "tags": [
+                    "Pods"
+                ],
+                "summary": "Describe Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of pod",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/pods/{pod_name}/disruptionbudgets": {
+            "get": {
+                "description": "List the PodDisruptionBudgets covering a pod",
+                "tags": [
+                    "Pods"
+                ],
+                "summary": "List Pod Disruption Budgets of Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of pod",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.DisruptionBudgetList"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/pods/{pod_name}/logs": {
+            "get": {
+                "description": "Get Pod Logs",
+                "tags": [
+                    "Pods"
+                ],
+                "summary": "Get Pod Logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of pod",
+                        "name": "pod_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of container",
+                        "name": "container",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of lines to show",
+                        "name": "tail",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/scale/{group}/{kind}/{name}": {
+            "get": {
+                "description": "Get the replicas of a workload of any kind exposing the scale subresource",
+                "tags": [
+                    "Scale"
+                ],
+                "summary": "Get Scale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API group of the kind, e.g. apps or argoproj.io; core for the core group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kind or resource name, e.g. StatefulSet or statefulsets",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the workload",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.Scale"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Scale a workload of any kind exposing the scale subresource, e.g. a StatefulSet or an Argo Rollout",
+                "tags": [
+                    "Scale"
+                ],
+                "summary": "Scale Workload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API group of the kind, e.g. apps or argoproj.io; core for the core group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kind or resource name, e.g. StatefulSet or statefulsets",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the workload",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Amount of Replicas",
+                        "name": "replicas",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum time to wait for the workload to be scaled, e.g. 90s; capped by the configured wait timeout",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Scale even though a horizontal pod autoscaler owns the replicas or a PodDisruptionBudget requires more healthy pods, with a warning",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown kind, or the kind is not scalable",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the workload, a horizontal pod autoscaler owns its replicas, or a PodDisruptionBudget requires more healthy pods",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "504": {
+                        "description": "The workload was not scaled in time",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/services": {
+            "get": {
+                "description": "List services of the namespace, one page at a time",
+                "tags": [
+                    "Services"
+                ],
+                "summary": "List Services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes label selector, e.g. app=payments",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes field selector, e.g. spec.type=LoadBalancer",
+                        "name": "fieldSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items in the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue token of the previous page",
+                        "name": "continue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the page: name or age, prefixed with - for descending",
+                        "name": "sortBy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ServiceList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad selector or query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "The continue token expired",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/kubernetes/{namespace}/statefulsets": {
+            "get": {
+                "description": "List statefulsets of the namespace, one page at a time",
+                "tags": [
+                    "StatefulSets"
+                ],
+                "summary": "List StatefulSets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes label selector, e.g. app=payments",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes field selector, e.g. metadata.name=db",
+                        "name": "fieldSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items in the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue token of the previous page",
+                        "name": "continue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the page: name or age, prefixed with - for descending",
+                        "name": "sortBy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.StatefulSetList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad selector or query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "The continue token expired",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nodes": {
+            "get": {
+                "description": "List nodes, one page at a time",
+                "tags": [
+                    "Nodes"
+                ],
+                "summary": "List Nodes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kubernetes label selector, e.g. app=payments",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kubernetes field selector, e.g. spec.unschedulable=true",
+                        "name": "fieldSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of items in the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Continue token of the previous page",
+                        "name": "continue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order of the page: name or age, prefixed with - for descending",
+                        "name": "sortBy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.NodeList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad selector or query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "410": {
+                        "description": "The continue token expired",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nodes/{node_name}": {
+            "get": {
+                "description": "Get the status, conditions and allocatable resources of a node",
+                "tags": [
+                    "Nodes"
+                ],
+                "summary": "Get Node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of node",
+                        "name": "node_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.NodeDetails"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nodes/{node_name}/cordon": {
+            "put": {
+                "description": "Mark a node unschedulable",
+                "tags": [
+                    "Nodes"
+                ],
+                "summary": "Cordon Node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of node",
+                        "name": "node_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the node",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nodes/{node_name}/drain": {
+            "put": {
+                "description": "Cordon a node and evict its pods through the Eviction API, which honours PodDisruptionBudgets.\nDaemonSet, static and completed pods are skipped. Evictions blocked by a budget are retried until the timeout.\nPods not managed by a controller would not be recreated, so they fail the drain unless it is forced.",
+                "tags": [
+                    "Nodes"
+                ],
+                "summary": "Drain Node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of node",
+                        "name": "node_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Termination grace period of the evicted pods, e.g. 30s; their own when absent",
+                        "name": "gracePeriod",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum time to wait for the pods to be evicted, e.g. 5m; capped by the configured wait timeout",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Evict pods not managed by a controller too, with a warning; they are not recreated",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Evicted and skipped pods",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the node, or some pods were not evicted; pods reports the outcome per pod",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nodes/{node_name}/uncordon": {
+            "put": {
+                "description": "Mark a node schedulable",
+                "tags": [
+                    "Nodes"
+                ],
+                "summary": "Uncordon Node",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of node",
+                        "name": "node_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and compute changes without applying them",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Waiting for approval",
+                        "schema": {
+                            "$ref": "#/definitions/views.ActionResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Refused by policy",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Another action is running on the node",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/views.Problem"
+                        }
+                    }
+                }
             }
         }
     },
     "definitions": {
+        "views.ActionResult": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "$ref": "#/definitions/views.Approval"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Change"
+                    }
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "pods": {
+                    "description": "Pods reports the outcome for each pod of an action acting on several.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PodResult"
+                    }
+                },
+                "replacement": {
+                    "description": "Replacement is the ready pod that replaced a restarted one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/views.Replacement"
+                        }
+                    ]
+                },
+                "warnings": {
+                    "description": "Warnings report safeguards the action was forced through.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "views.Approval": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "cluster": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "decidedAt": {
+                    "type": "string"
+                },
+                "decidedBy": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind is the kind of the workload a scale targets, e.g. StatefulSet.apps.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "requestedBy": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "targetReplicas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.AutoscalerCondition": {
+            "type": "object",
+            "properties": {
+                "lastTransitionTime": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AutoscalerMetric": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "Resource",
+                        "ContainerResource",
+                        "Pods",
+                        "Object",
+                        "External"
+                    ]
+                }
+            }
+        },
+        "views.AutoscalerTarget": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.Change": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.Cluster": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "default": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "serverVersion": {
+                    "type": "string"
+                }
+            }
+        },
         "views.Container": {
             "type": "object",
             "properties": {
@@ -331,49 +1906,327 @@ const docTemplate = `{
                 "cpuUsage": {
                     "type": "integer"
                 },
-                "memoryLimits": {
+                "memoryLimits": {
+                    "type": "integer"
+                },
+                "memoryUsage": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "restarts": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.Deployment": {
+            "type": "object",
+            "properties": {
+                "currentReplicas": {
+                    "type": "integer"
+                },
+                "disruptionsAllowed": {
+                    "description": "DisruptionsAllowed is the fewest disruptions the PodDisruptionBudgets\ncovering the pods allow; absent when none covers them.",
+                    "type": "integer"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readyReplicas": {
+                    "type": "integer"
+                },
+                "replicas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.DeploymentList": {
+            "type": "object",
+            "properties": {
+                "continue": {
+                    "type": "string"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Deployment"
+                    }
+                }
+            }
+        },
+        "views.DisruptionBudget": {
+            "type": "object",
+            "properties": {
+                "currentHealthy": {
+                    "type": "integer"
+                },
+                "desiredHealthy": {
+                    "type": "integer"
+                },
+                "disruptionsAllowed": {
+                    "type": "integer"
+                },
+                "expectedPods": {
+                    "type": "integer"
+                },
+                "maxUnavailable": {
+                    "type": "string"
+                },
+                "minAvailable": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.DisruptionBudgetList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.DisruptionBudget"
+                    }
+                }
+            }
+        },
+        "views.Freshness": {
+            "type": "object",
+            "properties": {
+                "observedAt": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "cache",
+                        "live"
+                    ]
+                }
+            }
+        },
+        "views.HorizontalPodAutoscaler": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.AutoscalerCondition"
+                    }
+                },
+                "currentReplicas": {
+                    "type": "integer"
+                },
+                "desiredReplicas": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "lastScaleTime": {
+                    "type": "string"
+                },
+                "maxReplicas": {
                     "type": "integer"
                 },
-                "memoryUsage": {
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.AutoscalerMetric"
+                    }
+                },
+                "minReplicas": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "state": {
+                "namespace": {
                     "type": "string"
+                },
+                "target": {
+                    "$ref": "#/definitions/views.AutoscalerTarget"
                 }
             }
         },
-        "views.Deployment": {
+        "views.Namespace": {
             "type": "object",
             "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
-                "replicas": {
-                    "type": "integer"
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.NamespaceList": {
+            "type": "object",
+            "properties": {
+                "continue": {
+                    "type": "string"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Namespace"
+                    }
                 }
             }
         },
-        "views.DeploymentPod": {
+        "views.Node": {
             "type": "object",
             "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "kubeletVersion": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
+                "ready": {
+                    "type": "boolean"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unschedulable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "views.NodeCondition": {
+            "type": "object",
+            "properties": {
+                "lastTransitionTime": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "views.DeploymentPods": {
+        "views.NodeDetails": {
             "type": "object",
             "properties": {
-                "pods": {
+                "age": {
+                    "type": "string"
+                },
+                "allocatable": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "capacity": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.NodeCondition"
+                    }
+                },
+                "kubeletVersion": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unschedulable": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "views.NodeList": {
+            "type": "object",
+            "properties": {
+                "continue": {
+                    "type": "string"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/views.DeploymentPod"
+                        "$ref": "#/definitions/views.Node"
                     }
                 }
             }
@@ -390,14 +2243,267 @@ const docTemplate = `{
                         "$ref": "#/definitions/views.Container"
                     }
                 },
+                "disruptionsAllowed": {
+                    "description": "DisruptionsAllowed is the fewest disruptions the PodDisruptionBudgets\ncovering the pod allow; absent when none covers it.",
+                    "type": "integer"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "ip": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
+                "node": {
+                    "type": "string"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
                 "restarts": {
                     "type": "integer"
                 },
+                "revision": {
+                    "description": "Revision is the deployment revision running in the pod; it is only\nset when listing the pods of a deployment.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.PodList": {
+            "type": "object",
+            "properties": {
+                "continue": {
+                    "type": "string"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Pod"
+                    }
+                }
+            }
+        },
+        "views.PodResult": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "evicted",
+                        "restarted",
+                        "skipped",
+                        "failed"
+                    ]
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "replacement": {
+                    "description": "Replacement is the ready pod that replaced a restarted one.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/views.Replacement"
+                        }
+                    ]
+                }
+            }
+        },
+        "views.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code identifies the problem for machines and does not change between\nreleases.",
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "pods": {
+                    "description": "Pods reports the outcome for each pod of an action that failed on\nsome of them.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.PodResult"
+                    }
+                },
+                "reason": {
+                    "description": "Reason is the reason reported by the Kubernetes API when it refused\nthe request.",
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
                 "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.Replacement": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "node": {
+                    "type": "string"
+                },
+                "timeToReady": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.Scale": {
+            "type": "object",
+            "properties": {
+                "currentReplicas": {
+                    "type": "integer"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "replicas": {
+                    "type": "integer"
+                },
+                "selector": {
+                    "description": "Selector selects the pods of the workload.",
+                    "type": "string"
+                }
+            }
+        },
+        "views.Service": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "clusterIP": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.ServicePort"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.ServiceList": {
+            "type": "object",
+            "properties": {
+                "continue": {
+                    "type": "string"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.Service"
+                    }
+                }
+            }
+        },
+        "views.ServicePort": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "integer"
+                },
+                "protocol": {
                     "type": "string"
+                },
+                "targetPort": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.StatefulSet": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "string"
+                },
+                "currentReplicas": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readyReplicas": {
+                    "type": "integer"
+                },
+                "replicas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.StatefulSetList": {
+            "type": "object",
+            "properties": {
+                "continue": {
+                    "type": "string"
+                },
+                "freshness": {
+                    "$ref": "#/definitions/views.Freshness"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.StatefulSet"
+                    }
                 }
             }
         }
@@ -2,22 +2,217 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/inviewteam/fenrir.executor/internal/domain/service"
 	"github.com/inviewteam/fenrir.executor/internal/infrastructure/kuber"
-	"k8s.io/client-go/rest"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/metrics"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/policy"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/storage"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/tracing"
+	log "github.com/sirupsen/logrus"
 )
 
 type Application struct {
+	// ExecutorService acts on the default cluster.
 	ExecutorService *service.Executor
+	Clusters        map[string]*Cluster
+	DefaultCluster  string
+	Metrics         *metrics.Metrics
+	Config          Config
 }
 
-func New(ctx context.Context, kubeConfig *rest.Config) (*Application, error) {
-	kRepo, err := kuber.New(kubeConfig)
+type Cluster struct {
+	Name            string
+	Host            string
+	ExecutorService *service.Executor
+	// Namespace is the default namespace of the kubeconfig context or of
+	// the service account the executor runs as.
+	Namespace string
+}
+
+type Config struct {
+	Executor    ExecutorConfig    `yaml:"executor"`
+	Policy      PolicyConfig      `yaml:"policy"`
+	Approvals   ApprovalsConfig   `yaml:"approvals"`
+	Operations  OperationsConfig  `yaml:"operations"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Auth        AuthConfig        `yaml:"auth"`
+}
+
+type ExecutorConfig struct {
+	// PollInterval is the first delay between checks while waiting for an
+	// action to complete when the object cannot be watched. It doubles after
+	// every check up to MaxPollInterval.
+	PollInterval    time.Duration `yaml:"pollInterval"`
+	MaxPollInterval time.Duration `yaml:"maxPollInterval"`
+	// WaitTimeout bounds how long an action waits for the cluster to converge,
+	// including the timeout a request asks for.
+	WaitTimeout time.Duration `yaml:"waitTimeout"`
+	// RestartMode is how pods are restarted unless a request asks otherwise:
+	// "evict" honours PodDisruptionBudgets, "delete" bypasses them.
+	RestartMode service.RestartMode `yaml:"restartMode"`
+}
+
+type PolicyConfig struct {
+	// File is the path to the YAML policy guarding executor actions.
+	// No policy is enforced when it is empty.
+	File string `yaml:"file,omitempty"`
+}
+
+type ApprovalsConfig struct {
+	// File persists actions waiting for approval. Approvals are kept
+	// in memory only when it is empty.
+	File string        `yaml:"file,omitempty"`
+	TTL  time.Duration `yaml:"ttl"`
+	// Retention is how long approvals are kept after they expired, so
+	// decisions can still be looked up.
+	Retention time.Duration `yaml:"retention"`
+}
+
+type OperationsConfig struct {
+	// File persists the state of running mutations, so those interrupted by
+	// a shutdown can be found. Operations are kept in memory only when it is
+	// empty.
+	File string `yaml:"file,omitempty"`
+	// TTL is how long finished operations are kept.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type IdempotencyConfig struct {
+	// TTL is how long responses are replayed for a repeated Idempotency-Key.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type AuthConfig struct {
+	// PrincipalHeader is the request header carrying the caller identity.
+	PrincipalHeader string `yaml:"principalHeader"`
+}
+
+var (
+	DefaultConfig = Config{
+		Executor: ExecutorConfig{
+			PollInterval:    time.Second * 5,
+			MaxPollInterval: time.Second * 30,
+			WaitTimeout:     time.Minute * 5,
+			RestartMode:     service.RestartEvict,
+		},
+		Approvals: ApprovalsConfig{
+			TTL:       time.Hour,
+			Retention: time.Hour * 24,
+		},
+		Operations: OperationsConfig{
+			TTL: time.Hour * 24,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: time.Hour * 24,
+		},
+		Auth: AuthConfig{
+			PrincipalHeader: "X-Remote-User",
+		},
+	}
+)
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Executor.PollInterval <= 0 {
+		errs = append(errs, errors.New("executor.pollInterval must be positive"))
+	}
+	if c.Executor.MaxPollInterval < c.Executor.PollInterval {
+		errs = append(errs, errors.New("executor.maxPollInterval must not be less than executor.pollInterval"))
+	}
+	if c.Executor.WaitTimeout <= 0 {
+		errs = append(errs, errors.New("executor.waitTimeout must be positive"))
+	}
+	if !c.Executor.RestartMode.Valid() {
+		errs = append(errs, fmt.Errorf("executor.restartMode: unknown mode %q", c.Executor.RestartMode))
+	}
+	if c.Approvals.TTL <= 0 {
+		errs = append(errs, errors.New("approvals.ttl must be positive"))
+	}
+	if c.Approvals.Retention < 0 {
+		errs = append(errs, errors.New("approvals.retention must not be negative"))
+	}
+	if c.Operations.TTL <= 0 {
+		errs = append(errs, errors.New("operations.ttl must be positive"))
+	}
+	if c.Idempotency.TTL <= 0 {
+		errs = append(errs, errors.New("idempotency.ttl must be positive"))
+	}
+	if c.Auth.PrincipalHeader == "" {
+		errs = append(errs, errors.New("auth.principalHeader is required"))
+	}
+	return errors.Join(errs...)
+}
+
+// New builds an executor for every configured cluster. The executors share
+// the policy, the approval store and the metrics.
+func New(ctx context.Context, kubeCfg kuber.Config, cfg Config) (*Application, error) {
+	appMetrics := metrics.New()
+	approvals, err := storage.NewApprovals(cfg.Approvals.File, cfg.Approvals.Retention)
 	if err != nil {
 		return nil, err
 	}
+	operations, err := storage.NewOperations(cfg.Operations.File, cfg.Operations.TTL)
+	if err != nil {
+		return nil, err
+	}
+	opts := []service.Option{
+		service.WithApprovals(approvals, cfg.Approvals.TTL),
+		service.WithOperations(operations),
+		service.WithMetrics(appMetrics),
+		service.WithWait(cfg.Executor.PollInterval, cfg.Executor.MaxPollInterval, cfg.Executor.WaitTimeout),
+		service.WithRestartMode(cfg.Executor.RestartMode),
+	}
+	if cfg.Policy.File != "" {
+		policyFile, err := policy.Load(cfg.Policy.File)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, service.WithPolicy(policy.New(policyFile)))
+	}
+
+	clusterConfigs, defaultCluster := kubeCfg.ClusterConfigs()
+	clusters := make(map[string]*Cluster, len(clusterConfigs))
+	for name, clusterCfg := range clusterConfigs {
+		kubeConfig, namespace, err := clusterCfg.RESTConfig()
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", name, err)
+		}
+		kubeConfig.Wrap(appMetrics.WrapTransport)
+		kubeConfig.Wrap(tracing.WrapTransport)
+
+		kRepo, err := kuber.New(ctx, kubeConfig, kubeCfg.Cache)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", name, err)
+		}
+		clusters[name] = &Cluster{
+			Name:            name,
+			Host:            kubeConfig.Host,
+			ExecutorService: service.New(kRepo, append(opts, service.WithCluster(name))...),
+			Namespace:       namespace,
+		}
+		log.WithFields(log.Fields{"cluster": name, "host": kubeConfig.Host, "namespace": namespace}).Info("kubernetes client configured")
+	}
+
 	return &Application{
-		service.New(kRepo),
+		ExecutorService: clusters[defaultCluster].ExecutorService,
+		Clusters:        clusters,
+		DefaultCluster:  defaultCluster,
+		Metrics:         appMetrics,
+		Config:          cfg,
 	}, nil
 }
+
+// Shutdown waits for the running operations of every cluster until ctx is
+// done and marks the remaining ones as interrupted.
+func (a *Application) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, cluster := range a.Clusters {
+		errs = append(errs, cluster.ExecutorService.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/inviewteam/fenrir.executor/internal/application"
+	server "github.com/inviewteam/fenrir.executor/internal/infrastructure/http"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/kuber"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/tracing"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix prefixes the environment variables overriding the file, e.g.
+// FENRIR_SERVER_ADDRESS or FENRIR_EXECUTOR_WAIT_TIMEOUT.
+const EnvPrefix = "FENRIR"
+
+// Config is the complete executor configuration. Settings are resolved from
+// defaults, then the YAML file, then FENRIR_* environment variables.
+type Config struct {
+	Server      server.Config      `yaml:"server"`
+	Kubernetes  kuber.Config       `yaml:"kubernetes"`
+	Log         LogConfig          `yaml:"log"`
+	Tracing     tracing.Config     `yaml:"tracing"`
+	Application application.Config `yaml:",inline"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server:      server.DefaultConfig,
+		Log:         LogConfig{Level: "info"},
+		Tracing:     tracing.DefaultConfig,
+		Application: application.DefaultConfig,
+	}
+}
+
+// Load resolves the configuration from the optional file and the environment
+// and validates the result.
+func Load(filename string) (*Config, error) {
+	cfg := Default()
+	if filename != "" {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config %s: %w", filename, err)
+		}
+	}
+	if err := applyEnv(EnvPrefix, os.LookupEnv, cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	if _, err := log.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if c.Server.Timeout.Write <= c.Application.Executor.WaitTimeout {
+		errs = append(errs, errors.New("server.timeout.write must exceed executor.waitTimeout, or waiting actions cannot reply"))
+	}
+	errs = append(errs,
+		c.Server.Validate(),
+		c.Kubernetes.Validate(),
+		c.Tracing.Validate(),
+		c.Application.Validate(),
+	)
+	return errors.Join(errs...)
+}
+
+func (c *Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"server", "SERVER"},
+		{"ttl", "TTL"},
+		{"waitTimeout", "WAIT_TIMEOUT"},
+		{"maxPollInterval", "MAX_POLL_INTERVAL"},
+		{"Kubeconfig", "KUBECONFIG"},
+		{"defaultCluster", "DEFAULT_CLUSTER"},
+	}
+	for _, tt := range tests {
+		if got := envName(tt.key); got != tt.want {
+			t.Errorf("envName(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+type envTestConfig struct {
+	Name    string        `yaml:"name"`
+	Enabled bool          `yaml:"enabled"`
+	Timeout time.Duration `yaml:"timeout"`
+	Retries int32         `yaml:"maxRetries"`
+	Ratio   float64       `yaml:"ratio,omitempty"`
+	Hosts   []string      `yaml:"hosts"`
+	Nested  struct {
+		WaitTimeout time.Duration `yaml:"waitTimeout"`
+	} `yaml:"nested"`
+	Inline struct {
+		Level string `yaml:"level"`
+	} `yaml:",inline"`
+	Untagged string
+	Ignored  string `yaml:"-"`
+	Ports    []int  `yaml:"ports"`
+}
+
+func TestApplyEnv(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(cfg *envTestConfig) bool
+		err   string
+	}{
+		{
+			name:  "nothing set",
+			check: func(cfg *envTestConfig) bool { return cfg.Name == "default" && cfg.Timeout == time.Second },
+		},
+		{
+			name:  "string",
+			env:   map[string]string{"APP_NAME": "web"},
+			check: func(cfg *envTestConfig) bool { return cfg.Name == "web" },
+		},
+		{
+			name:  "empty string",
+			env:   map[string]string{"APP_NAME": ""},
+			check: func(cfg *envTestConfig) bool { return cfg.Name == "" },
+		},
+		{
+			name:  "bool",
+			env:   map[string]string{"APP_ENABLED": "true"},
+			check: func(cfg *envTestConfig) bool { return cfg.Enabled },
+		},
+		{
+			name:  "duration",
+			env:   map[string]string{"APP_TIMEOUT": "1m30s"},
+			check: func(cfg *envTestConfig) bool { return cfg.Timeout == 90*time.Second },
+		},
+		{
+			name:  "camel case key",
+			env:   map[string]string{"APP_MAX_RETRIES": "3"},
+			check: func(cfg *envTestConfig) bool { return cfg.Retries == 3 },
+		},
+		{
+			name:  "float",
+			env:   map[string]string{"APP_RATIO": "0.5"},
+			check: func(cfg *envTestConfig) bool { return cfg.Ratio == 0.5 },
+		},
+		{
+			name:  "list",
+			env:   map[string]string{"APP_HOSTS": "a,b"},
+			check: func(cfg *envTestConfig) bool { return reflect.DeepEqual(cfg.Hosts, []string{"a", "b"}) },
+		},
+		{
+			name:  "nested",
+			env:   map[string]string{"APP_NESTED_WAIT_TIMEOUT": "5s"},
+			check: func(cfg *envTestConfig) bool { return cfg.Nested.WaitTimeout == 5*time.Second },
+		},
+		{
+			name:  "inline",
+			env:   map[string]string{"APP_LEVEL": "debug", "APP_INLINE_LEVEL": "warn"},
+			check: func(cfg *envTestConfig) bool { return cfg.Inline.Level == "debug" },
+		},
+		{
+			name:  "untagged",
+			env:   map[string]string{"APP_UNTAGGED": "x"},
+			check: func(cfg *envTestConfig) bool { return cfg.Untagged == "x" },
+		},
+		{
+			name:  "ignored",
+			env:   map[string]string{"APP_IGNORED": "x", "APP_-": "x"},
+			check: func(cfg *envTestConfig) bool { return cfg.Ignored == "" },
+		},
+		{
+			name: "bad bool",
+			env:  map[string]string{"APP_ENABLED": "maybe"},
+			err:  "bad value of APP_ENABLED",
+		},
+		{
+			name: "bad duration",
+			env:  map[string]string{"APP_TIMEOUT": "30"},
+			err:  "bad value of APP_TIMEOUT",
+		},
+		{
+			name: "int overflow",
+			env:  map[string]string{"APP_MAX_RETRIES": "3000000000"},
+			err:  "bad value of APP_MAX_RETRIES",
+		},
+		{
+			name: "unsupported type",
+			env:  map[string]string{"APP_PORTS": "80,443"},
+			err:  "bad value of APP_PORTS: unsupported type []int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &envTestConfig{Name: "default", Timeout: time.Second}
+			lookup := func(name string) (string, bool) {
+				value, ok := tt.env[name]
+				return value, ok
+			}
+
+			err := applyEnv("APP", lookup, cfg)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(cfg) {
+				t.Errorf("config = %+v", *cfg)
+			}
+		})
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	t.Setenv("FENRIR_SERVER_ADDRESS", ":9090")
+	t.Setenv("FENRIR_EXECUTOR_WAIT_TIMEOUT", "2m")
+	t.Setenv("FENRIR_KUBERNETES_NAMESPACE", "ops")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Address != ":9090" {
+		t.Errorf("server address = %q, want :9090", cfg.Server.Address)
+	}
+	if cfg.Application.Executor.WaitTimeout != 2*time.Minute {
+		t.Errorf("executor wait timeout = %s, want 2m", cfg.Application.Executor.WaitTimeout)
+	}
+	if cfg.Kubernetes.Namespace != "ops" {
+		t.Errorf("kubernetes namespace = %q, want ops", cfg.Kubernetes.Namespace)
+	}
+
+	t.Setenv("FENRIR_EXECUTOR_WAIT_TIMEOUT", "soon")
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "FENRIR_EXECUTOR_WAIT_TIMEOUT") {
+		t.Errorf("err = %v, want bad value of FENRIR_EXECUTOR_WAIT_TIMEOUT", err)
+	}
+}
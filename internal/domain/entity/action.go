@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// Change describes a single field modification made (or, in dry-run mode,
+// that would be made) by a mutating executor action.
+type Change struct {
+	Field string
+	From  string
+	To    string
+}
+
+type ActionResult struct {
+	DryRun  bool
+	Changes []*Change
+	// Warnings report safeguards the action was forced through.
+	Warnings []string
+	// Approval is set when the action was not executed but is waiting for approval.
+	Approval *Approval
+	// Pods reports what happened to each pod of an action acting on several.
+	Pods []*PodResult
+	// Replacement is the ready pod that replaced a restarted one.
+	Replacement *Replacement
+}
+
+type Replacement struct {
+	Name string
+	Node string
+	// TimeToReady is measured from the removal of the restarted pod.
+	TimeToReady time.Duration
+}
+
+type PodOutcome string
+
+const (
+	PodEvicted   PodOutcome = "evicted"
+	PodRestarted PodOutcome = "restarted"
+	PodSkipped   PodOutcome = "skipped"
+	PodFailed    PodOutcome = "failed"
+)
+
+// PodResult is the outcome of an action on one of its pods.
+type PodResult struct {
+	Namespace string
+	Name      string
+	Outcome   PodOutcome
+	// Reason explains a skipped or failed pod.
+	Reason   string
+	Duration time.Duration
+	// Replacement is the ready pod that replaced a restarted one.
+	Replacement *Replacement
+}
+
+func NewChange(field, from, to string) *Change {
+	return &Change{
+		Field: field,
+		From:  from,
+		To:    to,
+	}
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type ApprovalStatus string
+
+const (
+	ApprovalPending  ApprovalStatus = "pending"
+	ApprovalApproved ApprovalStatus = "approved"
+	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalExpired  ApprovalStatus = "expired"
+	ApprovalExecuted ApprovalStatus = "executed"
+	ApprovalFailed   ApprovalStatus = "failed"
+)
+
+// Approval is a high-risk action held back until a second principal decides on it.
+type Approval struct {
+	ID          string
+	Action      *Action
+	Rule        string
+	Status      ApprovalStatus
+	RequestedBy string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	DecidedBy   string
+	DecidedAt   time.Time
+	Error       string
+}
+
+type ApprovalRepository interface {
+	Save(ctx context.Context, approval *Approval) error
+	Get(ctx context.Context, id string) (*Approval, error)
+}
@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// HorizontalPodAutoscaler scales a workload between MinReplicas and
+// MaxReplicas. While it exists it owns the replica count of its target.
+type HorizontalPodAutoscaler struct {
+	Namespace string
+	Name      string
+	Labels    map[string]string
+	// TargetKind and TargetName identify the scaled workload, e.g. a
+	// Deployment.
+	TargetKind      string
+	TargetName      string
+	MinReplicas     int32
+	MaxReplicas     int32
+	CurrentReplicas int32
+	DesiredReplicas int32
+	// LastScaleTime is nil until the autoscaler scaled its target.
+	LastScaleTime *time.Time
+	Metrics       []*AutoscalerMetric
+	Conditions    []*AutoscalerCondition
+	Age           time.Duration
+}
+
+// AutoscalerMetric is a metric the autoscaler scales on.
+type AutoscalerMetric struct {
+	// Type is the metric source: Resource, ContainerResource, Pods, Object
+	// or External.
+	Type string
+	// Name is the resource or metric name, e.g. cpu.
+	Name string
+	// Target and Current are quantities, or percentages of the requests
+	// for utilization targets. Current is empty until observed.
+	Target  string
+	Current string
+}
+
+type AutoscalerCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DisruptionBudget limits how many of the pods it selects may be disrupted
+// voluntarily at once, e.g. by evictions.
+type DisruptionBudget struct {
+	Namespace string
+	Name      string
+	// MinAvailable and MaxUnavailable are a number of pods or a percentage
+	// such as "50%"; at most one of them is set.
+	MinAvailable   string
+	MaxUnavailable string
+	// CurrentHealthy, DesiredHealthy, ExpectedPods and DisruptionsAllowed
+	// are observed by the disruption controller.
+	CurrentHealthy     int32
+	DesiredHealthy     int32
+	ExpectedPods       int32
+	DisruptionsAllowed int32
+}
+
+// MinHealthy is how many of pods the budget requires to be healthy. Like the
+// disruption controller, it rounds percentages of pods up.
+func (b *DisruptionBudget) MinHealthy(pods int32) int32 {
+	switch {
+	case b.MinAvailable != "":
+		return scaledValue(b.MinAvailable, pods)
+	case b.MaxUnavailable != "":
+		return max(pods-scaledValue(b.MaxUnavailable, pods), 0)
+	}
+	return 0
+}
+
+// scaledValue resolves a number of pods or a percentage of pods. Malformed
+// values, which the API server rejects, count as zero.
+func scaledValue(value string, pods int32) int32 {
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.Atoi(percent)
+		if err != nil {
+			return 0
+		}
+		return int32(math.Ceil(float64(p) * float64(pods) / 100))
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return int32(n)
+}
+
+// MinDisruptionsAllowed returns the fewest disruptions any of the budgets
+// allows, or nil when there is no budget.
+func MinDisruptionsAllowed(budgets []*DisruptionBudget) *int32 {
+	var res *int32
+	for _, budget := range budgets {
+		if res == nil || budget.DisruptionsAllowed < *res {
+			allowed := budget.DisruptionsAllowed
+			res = &allowed
+		}
+	}
+	return res
+}
@@ -2,20 +2,55 @@ package entity
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
+// Freshness tells how current a read is. Reads served by a cache lag the
+// API server by the time its watch takes to deliver changes.
+type Freshness struct {
+	Cached bool
+	// ObservedAt is when the data was last known to match the API server.
+	ObservedAt time.Time
+}
+
 type Pod struct {
+	Namespace  string
 	Name       string
+	UID        string
 	Status     string
 	Restarts   int
 	Age        time.Duration
+	CreatedAt  time.Time
 	Containers []*Container
+	Labels     map[string]string
+	// Owner is the name of the workload controlling the pod, if any, and
+	// OwnerKind the kind of its direct controller, e.g. ReplicaSet.
+	Owner     string
+	OwnerKind string
+	// ControllerUID identifies the direct controller of the pod, which
+	// creates a replacement when the pod is removed.
+	ControllerUID string
+	// Mirror is set for static pods, which the API server only mirrors.
+	Mirror bool
+	Node   string
+	IP     string
+	Ready  bool
+	// Revision is the deployment revision of the pod's ReplicaSet, when known.
+	Revision string
+	// DisruptionsAllowed is the fewest disruptions the budgets covering the
+	// pod allow; nil when no budget covers it or it was not looked up.
+	DisruptionsAllowed *int32
+	Freshness          Freshness
 }
 
 type Container struct {
-	Name         string
-	State        string
+	Name  string
+	State string
+	// Reason explains a waiting or terminated state, e.g. CrashLoopBackOff.
+	Reason       string
+	Ready        bool
+	Restarts     int
 	CpuUsage     int64
 	MemoryUsage  int64
 	CpuLimits    int64
@@ -23,8 +58,49 @@ type Container struct {
 }
 
 type Deployment struct {
-	Name     string
-	Replicas int32
+	Name string
+	// Replicas is the desired number of pods; CurrentReplicas and
+	// ReadyReplicas are observed by the deployment controller.
+	Replicas        int32
+	CurrentReplicas int32
+	ReadyReplicas   int32
+	Labels          map[string]string
+	// PodLabels are the labels of the pod template.
+	PodLabels map[string]string
+	// DisruptionsAllowed is the fewest disruptions the budgets covering the
+	// pods allow; nil when no budget covers them or it was not looked up.
+	DisruptionsAllowed *int32
+	Freshness          Freshness
+}
+
+// ScaledTo reports whether the deployment runs exactly replicas ready pods.
+func (d *Deployment) ScaledTo(replicas int32) bool {
+	return d.Replicas == replicas && d.CurrentReplicas == replicas && d.ReadyReplicas == replicas
+}
+
+// failureReasons are the container waiting reasons a pod does not recover
+// from without an intervention.
+var failureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// FailureReason tells why the pod will not become ready, if it is stuck.
+func (p *Pod) FailureReason() string {
+	if p.Status == "Failed" {
+		return "pod failed"
+	}
+	for _, container := range p.Containers {
+		if failureReasons[container.Reason] {
+			return fmt.Sprintf("container %s: %s", container.Name, container.Reason)
+		}
+	}
+	return ""
 }
 
 func NewPod(name, status string, restarts int, age time.Duration, containers []*Container) *Pod {
@@ -38,14 +114,60 @@ func NewPod(name, status string, restarts int, age time.Duration, containers []*
 }
 
 type KubernetesRepository interface {
-	ListPodsByDeployment(ctx context.Context, namespace, deploymentName string) ([]*Pod, error)
+	// ListPodsByDeployment lists the pods of the deployment with their
+	// containers; their usage is only fetched withMetrics.
+	ListPodsByDeployment(ctx context.Context, namespace, deploymentName string, withMetrics bool) ([]*Pod, error)
+	ListNamespaces(ctx context.Context, opts ListOptions) (*List[*Namespace], error)
+	ListNodes(ctx context.Context, opts ListOptions) (*List[*Node], error)
+	ListPods(ctx context.Context, namespace string, opts ListOptions) (*List[*Pod], error)
+	ListDeployments(ctx context.Context, namespace string, opts ListOptions) (*List[*Deployment], error)
+	ListStatefulSets(ctx context.Context, namespace string, opts ListOptions) (*List[*StatefulSet], error)
+	ListServices(ctx context.Context, namespace string, opts ListOptions) (*List[*Service], error)
 	GetPodByName(ctx context.Context, namespace, name string) (*Pod, error)
 	GetPodContainers(ctx context.Context, namespace, name string) ([]*Container, error)
 	GetDeploymentByName(ctx context.Context, namespace, name string) (*Deployment, error)
-	Delete(ctx context.Context, namespace string, podName string) error
-	Scale(ctx context.Context, namespace, deploymentName string, replicas int32) error
+	// Delete deletes the pod, bypassing its disruption budgets. A nil
+	// gracePeriod keeps the pod's own.
+	Delete(ctx context.Context, namespace string, podName string, gracePeriod *time.Duration, dryRun bool) error
+	GetScale(ctx context.Context, namespace string, workload Workload) (*Scale, error)
+	// Scale sets the replicas of the workload through its scale subresource.
+	Scale(ctx context.Context, namespace string, workload Workload, replicas int32, dryRun bool) error
 	GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error)
 	DescribePod(ctx context.Context, namespace, podName string) (string, error)
 	DescribeDeployment(ctx context.Context, namespace, deploymentName string) (string, error)
-	Rollback(ctx context.Context, namespace, deploymentName string) error
+	Rollback(ctx context.Context, namespace, deploymentName string, dryRun bool) ([]*Change, error)
+	ServerVersion(ctx context.Context) (string, error)
+	CheckMetricsAPI(ctx context.Context) error
+	// WaitForPodDeletion blocks until the pod with the uid is gone or ctx is
+	// done. A pod recreated under the same name does not count.
+	WaitForPodDeletion(ctx context.Context, namespace, podName, uid string) error
+	// ListSiblingPods returns the UIDs of the other pods of the controller of
+	// original. Taken before original is removed, they are the pods that do
+	// not replace it.
+	ListSiblingPods(ctx context.Context, original *Pod) ([]string, error)
+	// GetReplacementPod returns the newest pod created by the controller of
+	// original that accept takes for its replacement, or ErrPodNotFound while
+	// there is none.
+	GetReplacementPod(ctx context.Context, original *Pod, accept func(*Pod) bool) (*Pod, error)
+	// WaitForReplacementPod blocks until condition holds for a pod of the
+	// controller of original that accept takes for its replacement and
+	// returns it, or until condition fails or ctx is done.
+	WaitForReplacementPod(ctx context.Context, original *Pod, accept func(*Pod) bool, condition func(*Pod) (bool, error)) (*Pod, error)
+	// WaitForReplicas blocks until the deployment is scaled to replicas or ctx is done.
+	WaitForReplicas(ctx context.Context, namespace, deploymentName string, replicas int32) error
+	GetNode(ctx context.Context, name string) (*Node, error)
+	SetUnschedulable(ctx context.Context, nodeName string, unschedulable, dryRun bool) error
+	ListPodsOnNode(ctx context.Context, nodeName string) ([]*Pod, error)
+	// Evict asks the API server to evict the pod, which honours its
+	// disruption budgets. A nil gracePeriod keeps the pod's own.
+	Evict(ctx context.Context, namespace, podName string, gracePeriod *time.Duration, dryRun bool) error
+	// ListDisruptionBudgets lists the budgets selecting pods with the labels.
+	// Live reads them from the API server even when they are cached, for
+	// safety checks that must not act on a stale status.
+	ListDisruptionBudgets(ctx context.Context, namespace string, podLabels map[string]string, live bool) ([]*DisruptionBudget, error)
+	GetHorizontalPodAutoscaler(ctx context.Context, namespace, name string) (*HorizontalPodAutoscaler, error)
+	// FindHorizontalPodAutoscaler returns the autoscaler scaling the
+	// workload, or nil when there is none.
+	FindHorizontalPodAutoscaler(ctx context.Context, namespace string, target Workload) (*HorizontalPodAutoscaler, error)
+	SetAutoscalerLimits(ctx context.Context, namespace, name string, minReplicas, maxReplicas int32, dryRun bool) error
 }
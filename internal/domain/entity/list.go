@@ -0,0 +1,83 @@
+package entity
+
+import "time"
+
+// ListOptions narrows a list of objects the way the Kubernetes API does.
+type ListOptions struct {
+	LabelSelector string
+	FieldSelector string
+	// Limit caps the number of items returned; Continue resumes a list
+	// cut by a previous Limit.
+	Limit    int64
+	Continue string
+	// SortBy orders the returned page by "name" or "age", prefixed with "-"
+	// for descending order. Pages are sorted one at a time, so sorting a
+	// paginated list does not order it as a whole.
+	SortBy string
+}
+
+// List is a page of objects.
+type List[T any] struct {
+	Items []T
+	// Continue is set when more items are available.
+	Continue  string
+	Freshness Freshness
+}
+
+type Namespace struct {
+	Name   string
+	Status string
+	Age    time.Duration
+	Labels map[string]string
+}
+
+type StatefulSet struct {
+	Name string
+	// Replicas is the desired number of pods; CurrentReplicas and
+	// ReadyReplicas are observed by the statefulset controller.
+	Replicas        int32
+	CurrentReplicas int32
+	ReadyReplicas   int32
+	Age             time.Duration
+	Labels          map[string]string
+}
+
+type Service struct {
+	Name      string
+	Type      string
+	ClusterIP string
+	Ports     []ServicePort
+	Age       time.Duration
+	Labels    map[string]string
+}
+
+type ServicePort struct {
+	Name       string
+	Protocol   string
+	Port       int32
+	TargetPort string
+}
+
+type Node struct {
+	Name  string
+	Ready bool
+	// Unschedulable is set on cordoned nodes.
+	Unschedulable  bool
+	Roles          []string
+	KubeletVersion string
+	Age            time.Duration
+	Labels         map[string]string
+	Conditions     []*NodeCondition
+	// Capacity and Allocatable map resource names to quantities, e.g.
+	// "cpu" to "3800m". Allocatable is what is left for pods.
+	Capacity    map[string]string
+	Allocatable map[string]string
+}
+
+type NodeCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type OperationStatus string
+
+const (
+	OperationRunning     OperationStatus = "running"
+	OperationSucceeded   OperationStatus = "succeeded"
+	OperationFailed      OperationStatus = "failed"
+	OperationInterrupted OperationStatus = "interrupted"
+)
+
+// Operation records a mutation from the moment it is applied until the
+// cluster converged, so mutations cut short by a shutdown can be found.
+type Operation struct {
+	ID          string
+	Action      *Action
+	Status      OperationStatus
+	RequestedBy string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Error       string
+}
+
+type OperationRepository interface {
+	Save(ctx context.Context, operation *Operation) error
+}
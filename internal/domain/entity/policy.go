@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+type ActionKind string
+
+const (
+	ActionRestart        ActionKind = "restart"
+	ActionRollingRestart ActionKind = "rolling-restart"
+	ActionScale          ActionKind = "scale"
+	ActionRollback       ActionKind = "rollback"
+	ActionCordon         ActionKind = "cordon"
+	ActionUncordon       ActionKind = "uncordon"
+	ActionDrain          ActionKind = "drain"
+	ActionAutoscale      ActionKind = "autoscale"
+)
+
+// Action describes a mutation the executor is about to perform. It carries
+// everything a policy needs to decide whether the mutation is allowed.
+type Action struct {
+	Kind    ActionKind
+	Cluster string
+	// Namespace is empty for cluster-scoped targets such as nodes.
+	Namespace string
+	Name      string
+	// Workload is the workload a scale action targets. It is nil for scale
+	// actions recorded before other kinds than deployments were scalable.
+	Workload *Workload
+	// Owner is the workload the target belongs to, e.g. the deployment of a pod.
+	Owner          string
+	Labels         map[string]string
+	Replicas       int32
+	TargetReplicas int32
+	// MinReplicas and MaxReplicas are the limits set on an autoscaler.
+	MinReplicas int32
+	MaxReplicas int32
+	// WaveSize and MaxFailures parameterize a rolling restart.
+	WaveSize    int
+	MaxFailures int
+	DryRun      bool
+	// Force is set when the caller overrode the executor's safeguards.
+	Force bool
+	// RestartMode, GracePeriod and Timeout are the options the action was
+	// requested with, so an approved action is executed as requested.
+	RestartMode string
+	GracePeriod *time.Duration
+	Timeout     time.Duration
+	// Approved is set when a human approved the action, which satisfies
+	// rules requiring approval.
+	Approved bool
+}
+
+type PolicyChecker interface {
+	Check(ctx context.Context, action *Action) error
+}
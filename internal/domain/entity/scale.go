@@ -0,0 +1,40 @@
+package entity
+
+// Workload identifies a scalable object by the group and kind of its API
+// type, e.g. apps and StatefulSet.
+type Workload struct {
+	// Group is empty for the core API group.
+	Group string
+	Kind  string
+	Name  string
+}
+
+// DeploymentWorkload identifies the deployment.
+func DeploymentWorkload(name string) Workload {
+	return Workload{Group: "apps", Kind: "Deployment", Name: name}
+}
+
+func (w Workload) IsDeployment() bool {
+	return w.Group == "apps" && w.Kind == "Deployment"
+}
+
+// String formats the workload as kind.group/name, e.g. StatefulSet.apps/web.
+func (w Workload) String() string {
+	kind := w.Kind
+	if w.Group != "" {
+		kind += "." + w.Group
+	}
+	return kind + "/" + w.Name
+}
+
+// Scale is the scale subresource of a workload.
+type Scale struct {
+	Workload Workload
+	Labels   map[string]string
+	// Replicas is the desired number of pods and CurrentReplicas the number
+	// observed by the controller of the workload.
+	Replicas        int32
+	CurrentReplicas int32
+	// Selector is the label selector of the pods of the workload.
+	Selector string
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WithApprovals enables the approval workflow: actions matching a policy rule
+// that requires approval are persisted in repo and expire after ttl.
+func WithApprovals(repo entity.ApprovalRepository, ttl time.Duration) Option {
+	return func(s *Executor) {
+		s.approvals = repo
+		s.approvalTTL = ttl
+	}
+}
+
+// authorize evaluates the policy for the action. When the policy requires a
+// human approval the action is stored as pending and returned as the result
+// instead of being executed.
+func (s *Executor) authorize(ctx context.Context, action *entity.Action) (*entity.ActionResult, error) {
+	err := s.checkPolicy(ctx, action)
+	var required *ApprovalRequiredError
+	if !errors.As(err, &required) || s.approvals == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	approval := &entity.Approval{
+		ID:          uuid.NewString(),
+		Action:      action,
+		Rule:        required.Rule,
+		Status:      entity.ApprovalPending,
+		RequestedBy: PrincipalFromContext(ctx),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.approvalTTL),
+	}
+	if err := s.approvals.Save(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to save approval: %w", err)
+	}
+	log.WithContext(ctx).Infof("%s of %s/%s is waiting for approval %s", action.Kind, action.Namespace, action.Name, approval.ID)
+	return &entity.ActionResult{Approval: approval}, nil
+}
+
+func (s *Executor) GetApproval(ctx context.Context, id string) (approval *entity.Approval, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetApproval", attribute.String("approval.id", id))
+	defer func() { endSpan(span, err) }()
+
+	if s.approvals == nil {
+		return nil, ErrApprovalNotFound
+	}
+	approval, err = s.approvals.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// The store may be shared by the executors of several clusters.
+	if approval.Action.Cluster != s.cluster {
+		return nil, ErrApprovalNotFound
+	}
+	if approval.Status == entity.ApprovalPending && time.Now().After(approval.ExpiresAt) {
+		approval.Status = entity.ApprovalExpired
+		if err := s.approvals.Save(ctx, approval); err != nil {
+			return nil, fmt.Errorf("failed to save approval: %w", err)
+		}
+	}
+	return approval, nil
+}
+
+// Approve executes the pending action on behalf of the calling principal.
+func (s *Executor) Approve(ctx context.Context, id string) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.Approve", attribute.String("approval.id", id))
+	defer func() { endSpan(span, err) }()
+
+	approval, err := s.decide(ctx, id, entity.ApprovalApproved)
+	if err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("Approval %s approved by %s", approval.ID, approval.DecidedBy)
+
+	result, err = s.execute(ctx, approval.Action)
+	approval.Status = entity.ApprovalExecuted
+	if err != nil {
+		approval.Status = entity.ApprovalFailed
+		approval.Error = err.Error()
+	}
+	if err := s.approvals.Save(ctx, approval); err != nil {
+		log.WithContext(ctx).Errorf("failed to save approval %s: %v", approval.ID, err)
+	}
+	return result, err
+}
+
+// Reject discards the pending action on behalf of the calling principal.
+func (s *Executor) Reject(ctx context.Context, id string) (approval *entity.Approval, err error) {
+	ctx, span := startSpan(ctx, "Executor.Reject", attribute.String("approval.id", id))
+	defer func() { endSpan(span, err) }()
+
+	approval, err = s.decide(ctx, id, entity.ApprovalRejected)
+	if err != nil {
+		return nil, err
+	}
+	log.WithContext(ctx).Infof("Approval %s rejected by %s", approval.ID, approval.DecidedBy)
+	return approval, nil
+}
+
+// decide moves a pending approval to the given status. The decision is
+// persisted before the action runs, so concurrent approvals execute it once.
+func (s *Executor) decide(ctx context.Context, id string, status entity.ApprovalStatus) (*entity.Approval, error) {
+	s.approvalMu.Lock()
+	defer s.approvalMu.Unlock()
+
+	approval, err := s.GetApproval(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status == entity.ApprovalExpired {
+		return nil, ErrApprovalExpired
+	}
+	if approval.Status != entity.ApprovalPending {
+		return nil, fmt.Errorf("%w: %s", ErrApprovalNotPending, approval.Status)
+	}
+	principal := PrincipalFromContext(ctx)
+	if principal == "" || principal == approval.RequestedBy {
+		return nil, ErrSelfApproval
+	}
+
+	approval.Status = status
+	approval.DecidedBy = principal
+	approval.DecidedAt = time.Now()
+	if err := s.approvals.Save(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to save approval: %w", err)
+	}
+	return approval, nil
+}
+
+func (s *Executor) execute(ctx context.Context, action *entity.Action) (*entity.ActionResult, error) {
+	opts := ActionOptions{
+		Timeout:     action.Timeout,
+		RestartMode: RestartMode(action.RestartMode),
+		GracePeriod: action.GracePeriod,
+		Force:       action.Force,
+		approved:    true,
+	}
+	switch action.Kind {
+	case entity.ActionRestart:
+		return s.Restart(ctx, action.Namespace, action.Name, opts)
+	case entity.ActionRollingRestart:
+		return s.RollingRestart(ctx, action.Namespace, action.Name, RollingRestartOptions{
+			ActionOptions: opts,
+			WaveSize:      action.WaveSize,
+			MaxFailures:   action.MaxFailures,
+		})
+	case entity.ActionScale:
+		if action.Workload != nil {
+			return s.ScaleWorkload(ctx, action.Namespace, *action.Workload, action.TargetReplicas, opts)
+		}
+		return s.Scale(ctx, action.Namespace, action.Name, action.TargetReplicas, opts)
+	case entity.ActionRollback:
+		return s.Rollback(ctx, action.Namespace, action.Name, opts)
+	case entity.ActionCordon:
+		return s.Cordon(ctx, action.Name, opts)
+	case entity.ActionUncordon:
+		return s.Uncordon(ctx, action.Name, opts)
+	case entity.ActionDrain:
+		return s.Drain(ctx, action.Name, opts)
+	case entity.ActionAutoscale:
+		return s.SetAutoscalerLimits(ctx, action.Namespace, action.Name, &action.MinReplicas, &action.MaxReplicas, opts)
+	default:
+		return nil, fmt.Errorf("unknown action %q", action.Kind)
+	}
+}
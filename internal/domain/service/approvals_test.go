@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+type memoryApprovals map[string]entity.Approval
+
+func (m memoryApprovals) Save(ctx context.Context, approval *entity.Approval) error {
+	m[approval.ID] = *approval
+	return nil
+}
+
+func (m memoryApprovals) Get(ctx context.Context, id string) (*entity.Approval, error) {
+	approval, ok := m[id]
+	if !ok {
+		return nil, ErrApprovalNotFound
+	}
+	return &approval, nil
+}
+
+type policyFunc func(ctx context.Context, action *entity.Action) error
+
+func (f policyFunc) Check(ctx context.Context, action *entity.Action) error {
+	return f(ctx, action)
+}
+
+func TestAuthorizeHoldsAction(t *testing.T) {
+	repo := memoryApprovals{}
+	s := New(nil, WithCluster("prod"), WithApprovals(repo, time.Hour),
+		WithPolicy(policyFunc(func(ctx context.Context, action *entity.Action) error {
+			if action.Approved {
+				return nil
+			}
+			return &ApprovalRequiredError{Rule: "big-scale-ups"}
+		})))
+	ctx := WithPrincipal(context.Background(), "alice")
+
+	result, err := s.authorize(ctx, &entity.Action{Kind: entity.ActionScale, Cluster: "prod", Name: "web", TargetReplicas: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil || result.Approval == nil {
+		t.Fatalf("result = %+v, want a pending approval", result)
+	}
+	approval, err := s.GetApproval(ctx, result.Approval.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approval.Status != entity.ApprovalPending || approval.RequestedBy != "alice" || approval.Rule != "big-scale-ups" {
+		t.Errorf("approval = %+v", approval)
+	}
+	if d := approval.ExpiresAt.Sub(approval.CreatedAt); d != time.Hour {
+		t.Errorf("approval expires after %s, want 1h", d)
+	}
+
+	// Without a store the policy refusal is returned as is.
+	s.approvals = nil
+	var required *ApprovalRequiredError
+	if _, err := s.authorize(ctx, &entity.Action{Kind: entity.ActionScale}); !errors.As(err, &required) {
+		t.Errorf("err = %v, want approval required", err)
+	}
+}
+
+func TestReject(t *testing.T) {
+	now := time.Now()
+	pending := entity.Approval{
+		ID:          "a1",
+		Action:      &entity.Action{Kind: entity.ActionScale, Cluster: "prod", Name: "web"},
+		Status:      entity.ApprovalPending,
+		RequestedBy: "alice",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	tests := []struct {
+		name      string
+		approval  func(a *entity.Approval)
+		principal string
+		err       error
+		status    entity.ApprovalStatus
+	}{
+		{"other principal", nil, "bob", nil, entity.ApprovalRejected},
+		{"requester", nil, "alice", ErrSelfApproval, entity.ApprovalPending},
+		{"anonymous", nil, "", ErrSelfApproval, entity.ApprovalPending},
+		{"expired", func(a *entity.Approval) { a.ExpiresAt = now.Add(-time.Second) }, "bob", ErrApprovalExpired, entity.ApprovalExpired},
+		{"already decided", func(a *entity.Approval) { a.Status = entity.ApprovalExecuted }, "bob", ErrApprovalNotPending, entity.ApprovalExecuted},
+		{"other cluster", func(a *entity.Approval) { a.Action = &entity.Action{Cluster: "staging"} }, "bob", ErrApprovalNotFound, entity.ApprovalPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval := pending
+			if tt.approval != nil {
+				tt.approval(&approval)
+			}
+			repo := memoryApprovals{approval.ID: approval}
+			s := New(nil, WithCluster("prod"), WithApprovals(repo, time.Hour))
+
+			_, err := s.Reject(WithPrincipal(context.Background(), tt.principal), approval.ID)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+			stored := repo[approval.ID]
+			if stored.Status != tt.status {
+				t.Errorf("status = %s, want %s", stored.Status, tt.status)
+			}
+			if tt.err == nil && stored.DecidedBy != tt.principal {
+				t.Errorf("decided by %q, want %q", stored.DecidedBy, tt.principal)
+			}
+		})
+	}
+}
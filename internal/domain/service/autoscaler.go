@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+)
+
+func (s *Executor) GetHorizontalPodAutoscaler(ctx context.Context, namespace, name string) (hpa *entity.HorizontalPodAutoscaler, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetHorizontalPodAutoscaler", objectAttributes(namespace, name)...)
+	defer func() { endSpan(span, err) }()
+
+	hpa, err = s.kubeRepo.GetHorizontalPodAutoscaler(ctx, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get horizontal pod autoscaler: %w", err)
+	}
+	return hpa, nil
+}
+
+// SetAutoscalerLimits changes the replica range of the autoscaler, which is
+// how to scale a workload the autoscaler owns. A nil limit is kept.
+func (s *Executor) SetAutoscalerLimits(ctx context.Context, namespace, name string, minReplicas, maxReplicas *int32, opts ActionOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.SetAutoscalerLimits", objectAttributes(namespace, name)...)
+	finish := s.track(entity.ActionAutoscale)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
+
+	if minReplicas == nil && maxReplicas == nil {
+		return nil, &InvalidArgumentError{Argument: "minReplicas", Reason: "minReplicas or maxReplicas is required"}
+	}
+	unlock, err := s.lock(namespace, "horizontalpodautoscaler", name, "set limits", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	hpa, err := s.kubeRepo.GetHorizontalPodAutoscaler(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	newMin, newMax := hpa.MinReplicas, hpa.MaxReplicas
+	if minReplicas != nil {
+		newMin = *minReplicas
+	}
+	if maxReplicas != nil {
+		newMax = *maxReplicas
+	}
+	log.WithContext(ctx).Infof("Set limits of horizontal pod autoscaler %s to %d-%d (dry run: %t)", name, newMin, newMax, opts.DryRun)
+	if newMin < 1 {
+		return nil, &InvalidArgumentError{Argument: "minReplicas", Reason: "must be positive"}
+	}
+	if newMax < newMin {
+		return nil, &InvalidArgumentError{Argument: "maxReplicas", Reason: fmt.Sprintf("must not be below minReplicas %d", newMin)}
+	}
+	action := &entity.Action{
+		Kind:        entity.ActionAutoscale,
+		Cluster:     s.cluster,
+		Namespace:   namespace,
+		Name:        name,
+		Owner:       hpa.TargetName,
+		Labels:      hpa.Labels,
+		Replicas:    hpa.CurrentReplicas,
+		MinReplicas: newMin,
+		MaxReplicas: newMax,
+		DryRun:      opts.DryRun,
+		Approved:    opts.approved,
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil || pending != nil {
+		return pending, err
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+	err = s.kubeRepo.SetAutoscalerLimits(ctx, namespace, name, newMin, newMax, opts.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	result = &entity.ActionResult{DryRun: opts.DryRun}
+	if newMin != hpa.MinReplicas {
+		result.Changes = append(result.Changes, entity.NewChange("minReplicas",
+			strconv.Itoa(int(hpa.MinReplicas)), strconv.Itoa(int(newMin))))
+	}
+	if newMax != hpa.MaxReplicas {
+		result.Changes = append(result.Changes, entity.NewChange("maxReplicas",
+			strconv.Itoa(int(hpa.MaxReplicas)), strconv.Itoa(int(newMax))))
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListPodDisruptionBudgets lists the budgets covering the pod.
+func (s *Executor) ListPodDisruptionBudgets(ctx context.Context, namespace, podName string) (budgets []*entity.DisruptionBudget, err error) {
+	ctx, span := startSpan(ctx, "Executor.ListPodDisruptionBudgets", objectAttributes(namespace, podName)...)
+	defer func() { endSpan(span, err) }()
+
+	pod, err := s.kubeRepo.GetPodByName(ctx, namespace, podName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	budgets, err = s.kubeRepo.ListDisruptionBudgets(ctx, namespace, pod.Labels, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	return budgets, nil
+}
+
+// ListDeploymentDisruptionBudgets lists the budgets covering the pods of the
+// deployment.
+func (s *Executor) ListDeploymentDisruptionBudgets(ctx context.Context, namespace, deploymentName string) (budgets []*entity.DisruptionBudget, err error) {
+	ctx, span := startSpan(ctx, "Executor.ListDeploymentDisruptionBudgets", objectAttributes(namespace, deploymentName)...)
+	defer func() { endSpan(span, err) }()
+
+	deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	budgets, err = s.kubeRepo.ListDisruptionBudgets(ctx, namespace, deployment.PodLabels, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+	}
+	return budgets, nil
+}
+
+// disruptionsAllowed looks up how many disruptions the budgets covering pods
+// with the labels allow, for views. A failed lookup is only logged.
+func (s *Executor) disruptionsAllowed(ctx context.Context, namespace string, podLabels map[string]string) *int32 {
+	budgets, err := s.kubeRepo.ListDisruptionBudgets(ctx, namespace, podLabels, false)
+	if err != nil {
+		log.WithContext(ctx).Errorf("failed to get pod disruption budgets: %v", err)
+		return nil
+	}
+	return entity.MinDisruptionsAllowed(budgets)
+}
+
+// checkPodDisruption refuses to take down a ready pod whose budgets allow no
+// disruption. Forced, it returns the violations as warnings instead. When
+// several pods go down together, admitted counts the disruptions already
+// taken from each budget by name, and the pod is added to it.
+func (s *Executor) checkPodDisruption(ctx context.Context, pod *entity.Pod, opts ActionOptions, admitted map[string]int32) ([]string, error) {
+	if !pod.Ready {
+		// The pod does not count as healthy, so removing it disrupts nothing.
+		return nil, nil
+	}
+	budgets, err := s.kubeRepo.ListDisruptionBudgets(ctx, pod.Namespace, pod.Labels, true)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, budget := range budgets {
+		taken := admitted[budget.Name]
+		if budget.DisruptionsAllowed > taken {
+			continue
+		}
+		reason := fmt.Sprintf("allows no disruption: %d healthy pods, %d required", budget.CurrentHealthy, budget.DesiredHealthy)
+		if taken > 0 {
+			reason = fmt.Sprintf("allows %d disruptions, all taken by the pods going down with this one", budget.DisruptionsAllowed)
+		}
+		if err := disruptionViolation(budget, reason, opts, &warnings); err != nil {
+			return nil, err
+		}
+	}
+	if admitted != nil {
+		for _, budget := range budgets {
+			admitted[budget.Name]++
+		}
+	}
+	return warnings, nil
+}
+
+// checkScaleDown refuses to scale the workload below the number of healthy
+// pods its budgets require. Forced, it returns the violations as warnings
+// instead.
+func (s *Executor) checkScaleDown(ctx context.Context, namespace string, sc *entity.Scale, targetReplicas int32, opts ActionOptions) ([]string, error) {
+	if targetReplicas >= sc.Replicas || sc.Selector == "" {
+		return nil, nil
+	}
+	// The budgets covering the pods to remove are found by the labels of
+	// one of them; a workload without pods has nothing to disrupt.
+	pods, err := s.kubeRepo.ListPods(ctx, namespace, entity.ListOptions{LabelSelector: sc.Selector, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+	budgets, err := s.kubeRepo.ListDisruptionBudgets(ctx, namespace, pods.Items[0].Labels, true)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	for _, budget := range budgets {
+		minHealthy := budget.MinHealthy(targetReplicas)
+		if targetReplicas >= minHealthy {
+			continue
+		}
+		reason := fmt.Sprintf("requires %d healthy pods, more than %d replicas", minHealthy, targetReplicas)
+		if err := disruptionViolation(budget, reason, opts, &warnings); err != nil {
+			return nil, err
+		}
+	}
+	return warnings, nil
+}
+
+func disruptionViolation(budget *entity.DisruptionBudget, reason string, opts ActionOptions, warnings *[]string) error {
+	if !opts.Force {
+		return &DisruptionBudgetError{Budget: budget.Name, Reason: reason}
+	}
+	*warnings = append(*warnings, fmt.Sprintf("pod disruption budget %s %s", budget.Name, reason))
+	return nil
+}
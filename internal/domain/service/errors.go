@@ -1,9 +1,259 @@
 package service
 
-import "errors"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+// Kind classifies domain errors. Transports map kinds to their status codes.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindInvalid
+	KindNotFound
+	KindForbidden
+	KindConflict
+	KindGone
+	KindTimeout
+	// KindTooManyRequests reports a refusal the caller may retry later.
+	KindTooManyRequests
+)
+
+// Error is a domain error with a stable, machine-readable code. The sentinel
+// errors below are *Error values, so errors.As classifies any error wrapping
+// one of them.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+}
+
+func newError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
 
 var (
-	ErrPodNotFound              = errors.New("pod not found")
-	ErrDeploymentNotFound       = errors.New("deployment not found")
-	ErrNoPreviousRevisionsFound = errors.New("no previous revisions")
+	ErrInvalidArgument          = newError(KindInvalid, "invalid_argument", "invalid argument")
+	ErrPodNotFound              = newError(KindNotFound, "pod_not_found", "pod not found")
+	ErrDeploymentNotFound       = newError(KindNotFound, "deployment_not_found", "deployment not found")
+	ErrNodeNotFound             = newError(KindNotFound, "node_not_found", "node not found")
+	ErrWorkloadNotFound         = newError(KindNotFound, "workload_not_found", "workload not found")
+	ErrNotScalable              = newError(KindInvalid, "not_scalable", "kind is not scalable")
+	ErrAutoscalerNotFound       = newError(KindNotFound, "autoscaler_not_found", "horizontal pod autoscaler not found")
+	ErrDisruptionBudget         = newError(KindConflict, "disruption_budget", "action would violate a pod disruption budget")
+	ErrManagedByAutoscaler      = newError(KindConflict, "managed_by_autoscaler", "replicas are managed by a horizontal pod autoscaler")
+	ErrEvictionBlocked          = newError(KindTooManyRequests, "eviction_blocked", "eviction blocked by a pod disruption budget")
+	ErrDrainIncomplete          = newError(KindConflict, "drain_incomplete", "node not drained")
+	ErrReplacementFailed        = newError(KindConflict, "replacement_failed", "replacement pod failed")
+	ErrRollingRestartAborted    = newError(KindConflict, "rolling_restart_aborted", "rolling restart aborted")
+	ErrNoPreviousRevisionsFound = newError(KindInvalid, "no_previous_revision", "no previous revisions")
+	ErrPolicyViolation          = newError(KindForbidden, "policy_violation", "policy violation")
+	ErrApprovalRequired         = newError(KindForbidden, "approval_required", "approval required")
+	ErrApprovalNotFound         = newError(KindNotFound, "approval_not_found", "approval not found")
+	ErrApprovalNotPending       = newError(KindConflict, "approval_not_pending", "approval is not pending")
+	ErrApprovalExpired          = newError(KindGone, "approval_expired", "approval expired")
+	ErrSelfApproval             = newError(KindForbidden, "self_approval", "approval must be decided by a different principal")
+	ErrResourceBusy             = newError(KindConflict, "resource_busy", "resource is busy")
+	ErrTimeout                  = newError(KindTimeout, "timeout", "timed out")
 )
+
+// InvalidArgumentError reports a malformed or out of range argument.
+type InvalidArgumentError struct {
+	Argument string
+	Reason   string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("%s %s: %s", ErrInvalidArgument, e.Argument, e.Reason)
+}
+
+func (e *InvalidArgumentError) Unwrap() error {
+	return ErrInvalidArgument
+}
+
+// PolicyViolationError reports the policy rule that refused an action.
+type PolicyViolationError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("%s: rule %q: %s", ErrPolicyViolation, e.Rule, e.Reason)
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// ApprovalRequiredError reports the policy rule that requires a human to
+// approve an action before it is executed.
+type ApprovalRequiredError struct {
+	Rule string
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	return fmt.Sprintf("%s: rule %q", ErrApprovalRequired, e.Rule)
+}
+
+func (e *ApprovalRequiredError) Unwrap() error {
+	return ErrApprovalRequired
+}
+
+// ResourceBusyError reports the operation currently holding the lock of a resource.
+type ResourceBusyError struct {
+	Resource  string
+	Operation string
+	Since     time.Time
+}
+
+func (e *ResourceBusyError) Error() string {
+	return fmt.Sprintf("%s: %s is running %s since %s",
+		ErrResourceBusy, e.Resource, e.Operation, e.Since.Format(time.RFC3339))
+}
+
+func (e *ResourceBusyError) Unwrap() error {
+	return ErrResourceBusy
+}
+
+// TimeoutError reports an action whose change was applied but which the
+// cluster did not complete in time.
+type TimeoutError struct {
+	Operation string
+	Timeout   time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s: %s did not complete within %s", ErrTimeout, e.Operation, e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return ErrTimeout
+}
+
+// EvictionBlockedError reports an eviction refused because it would violate
+// a PodDisruptionBudget. It may succeed once the budget allows disruptions
+// again, which the API server expects after RetryAfter when set.
+type EvictionBlockedError struct {
+	Namespace  string
+	Pod        string
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *EvictionBlockedError) Error() string {
+	return fmt.Sprintf("%s: %s/%s: %s", ErrEvictionBlocked, e.Namespace, e.Pod, e.Reason)
+}
+
+func (e *EvictionBlockedError) Unwrap() error {
+	return ErrEvictionBlocked
+}
+
+// DrainIncompleteError reports the pods a drain could not evict. Pods holds
+// the outcome for every pod of the node.
+type DrainIncompleteError struct {
+	Node   string
+	Failed []*entity.PodResult
+	Pods   []*entity.PodResult
+}
+
+func (e *DrainIncompleteError) Error() string {
+	pods := make([]string, 0, len(e.Failed))
+	for _, pod := range e.Failed {
+		pods = append(pods, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, pod.Reason))
+	}
+	return fmt.Sprintf("%s: %s: %d pods not evicted: %s", ErrDrainIncomplete, e.Node, len(e.Failed), strings.Join(pods, ", "))
+}
+
+func (e *DrainIncompleteError) Unwrap() error {
+	return ErrDrainIncomplete
+}
+
+// ReplacementFailedError reports a replacement pod that will not become
+// ready, e.g. because its containers crash.
+type ReplacementFailedError struct {
+	Pod    string
+	Reason string
+}
+
+func (e *ReplacementFailedError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrReplacementFailed, e.Pod, e.Reason)
+}
+
+func (e *ReplacementFailedError) Unwrap() error {
+	return ErrReplacementFailed
+}
+
+// RollingRestartAbortedError reports a rolling restart cancelled because more
+// than MaxFailures pods failed to be replaced. Pods holds the outcome for
+// every pod of the deployment.
+type RollingRestartAbortedError struct {
+	Deployment  string
+	MaxFailures int
+	Failed      []*entity.PodResult
+	Pods        []*entity.PodResult
+}
+
+func (e *RollingRestartAbortedError) Error() string {
+	pods := make([]string, 0, len(e.Failed))
+	for _, pod := range e.Failed {
+		pods = append(pods, fmt.Sprintf("%s (%s)", pod.Name, pod.Reason))
+	}
+	return fmt.Sprintf("%s: %s: %d pods failed, %d allowed: %s", ErrRollingRestartAborted, e.Deployment,
+		len(e.Failed), e.MaxFailures, strings.Join(pods, ", "))
+}
+
+func (e *RollingRestartAbortedError) Unwrap() error {
+	return ErrRollingRestartAborted
+}
+
+// ManagedByAutoscalerError reports a scale refused because an autoscaler
+// owns the replica count and would revert it.
+type ManagedByAutoscalerError struct {
+	Workload   entity.Workload
+	Autoscaler *entity.HorizontalPodAutoscaler
+}
+
+func (e *ManagedByAutoscalerError) Error() string {
+	return fmt.Sprintf("%s: %s is scaled by %s between %d and %d replicas; change its limits or force the scale",
+		ErrManagedByAutoscaler, e.Workload, e.Autoscaler.Name, e.Autoscaler.MinReplicas, e.Autoscaler.MaxReplicas)
+}
+
+func (e *ManagedByAutoscalerError) Unwrap() error {
+	return ErrManagedByAutoscaler
+}
+
+// DisruptionBudgetError reports an action refused because it would take more
+// pods down than a PodDisruptionBudget allows.
+type DisruptionBudgetError struct {
+	Budget string
+	Reason string
+}
+
+func (e *DisruptionBudgetError) Error() string {
+	return fmt.Sprintf("%s: %s %s; force the action to proceed anyway", ErrDisruptionBudget, e.Budget, e.Reason)
+}
+
+func (e *DisruptionBudgetError) Unwrap() error {
+	return ErrDisruptionBudget
+}
+
+// NotScalableError reports a kind without a scale subresource.
+type NotScalableError struct {
+	Kind string
+}
+
+func (e *NotScalableError) Error() string {
+	return fmt.Sprintf("%s: %s has no scale subresource", ErrNotScalable, e.Kind)
+}
+
+func (e *NotScalableError) Unwrap() error {
+	return ErrNotScalable
+}
@@ -3,75 +3,276 @@ package service
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Executor struct {
+	cluster  string
 	kubeRepo entity.KubernetesRepository
+	policy   entity.PolicyChecker
+	locks    *lockManager
+	metrics  Metrics
+
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	waitTimeout     time.Duration
+	restartMode     RestartMode
+
+	approvals   entity.ApprovalRepository
+	approvalTTL time.Duration
+	approvalMu  sync.Mutex
+
+	operations  entity.OperationRepository
+	operationMu sync.Mutex
+	running     map[string]*entity.Operation
+	drained     chan struct{}
 }
 
-func New(pRepo entity.KubernetesRepository) *Executor {
-	return &Executor{
-		kubeRepo: pRepo,
+type Option func(*Executor)
+
+// WithPolicy makes the executor consult the checker before every mutation.
+func WithPolicy(policy entity.PolicyChecker) Option {
+	return func(s *Executor) {
+		s.policy = policy
 	}
 }
 
-func (s *Executor) Restart(ctx context.Context, namespace, podName string) error {
-	log.Infof("Restart pod %s", podName)
-	err := s.kubeRepo.Delete(ctx, namespace, podName)
-	if err != nil {
-		return err
+// WithCluster names the cluster the executor acts on. Actions and approvals
+// are tagged with it.
+func WithCluster(name string) Option {
+	return func(s *Executor) {
+		s.cluster = name
 	}
+}
 
-	for {
-		_, err := s.kubeRepo.GetPodByName(ctx, namespace, podName)
-		if err != nil {
-			if err == ErrPodNotFound {
-				break
-			}
-			return err
-		}
-		log.Infof("Wait when pod %s restart", podName)
-		time.Sleep(5 * time.Second)
+// WithWait sets how long actions wait for the cluster to converge and, when
+// watching is not possible, how often they poll it. The poll interval doubles
+// after every poll up to maxPollInterval.
+func WithWait(pollInterval, maxPollInterval, timeout time.Duration) Option {
+	return func(s *Executor) {
+		s.pollInterval = pollInterval
+		s.maxPollInterval = maxPollInterval
+		s.waitTimeout = timeout
 	}
-	return nil
 }
 
-func (s *Executor) Scale(ctx context.Context, namespace, deploymentName string, targetReplicas int32) error {
-	log.Infof("Scale deployment %s to replicas %d", deploymentName, targetReplicas)
-	deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+func New(pRepo entity.KubernetesRepository, opts ...Option) *Executor {
+	s := &Executor{
+		kubeRepo:        pRepo,
+		locks:           newLockManager(),
+		pollInterval:    5 * time.Second,
+		maxPollInterval: 30 * time.Second,
+		waitTimeout:     5 * time.Minute,
+		restartMode:     RestartEvict,
+		running:         make(map[string]*entity.Operation),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// lock serializes mutations of one object. Dry runs change nothing and are
+// not serialized.
+func (s *Executor) lock(namespace, kind, name, operation string, opts ActionOptions) (func(), error) {
+	if opts.DryRun {
+		return func() {}, nil
+	}
+	return s.locks.tryLock(namespace, kind, name, operation)
+}
+
+func (s *Executor) checkPolicy(ctx context.Context, action *entity.Action) error {
+	if s.policy == nil {
+		return nil
+	}
+	return s.policy.Check(ctx, action)
+}
+
+func (s *Executor) Restart(ctx context.Context, namespace, podName string, opts ActionOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.Restart", objectAttributes(namespace, podName)...)
+	finish := s.track(entity.ActionRestart)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
+
+	mode, err := s.restartModeFor(opts)
 	if err != nil {
-		return fmt.Errorf("failed to scale: %w", err)
+		return nil, err
 	}
-	if deployment == nil {
-		return fmt.Errorf("failed to scale: %w", ErrDeploymentNotFound)
+	log.WithContext(ctx).Infof("Restart pod %s by %s (dry run: %t)", podName, mode, opts.DryRun)
+	unlock, err := s.lock(namespace, "pod", podName, "restart", opts)
+	if err != nil {
+		return nil, err
 	}
-	err = s.kubeRepo.Scale(ctx, namespace, deploymentName, targetReplicas)
+	defer unlock()
+
+	pod, err := s.kubeRepo.GetPodByName(ctx, namespace, podName)
 	if err != nil {
-		return fmt.Errorf("failed to scale: %w", err)
+		return nil, err
 	}
+	warnings, err := s.checkPodDisruption(ctx, pod, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	action := &entity.Action{
+		Kind:        entity.ActionRestart,
+		Cluster:     s.cluster,
+		Namespace:   namespace,
+		Name:        podName,
+		Owner:       pod.Owner,
+		Labels:      pod.Labels,
+		DryRun:      opts.DryRun,
+		Force:       opts.Force,
+		RestartMode: string(mode),
+		GracePeriod: opts.GracePeriod,
+		Timeout:     opts.Timeout,
+		Approved:    opts.approved,
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil || pending != nil {
+		return pending, err
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+	replacement, err := s.replacePod(ctx, pod, mode, opts, false, newReplacementClaims())
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ActionResult{
+		DryRun:      opts.DryRun,
+		Changes:     []*entity.Change{entity.NewChange("pod", pod.Name, "")},
+		Warnings:    warnings,
+		Replacement: replacement,
+	}, nil
+}
 
-	for {
-		deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
-		if err != nil {
-			return fmt.Errorf("failed to scale: %w", err)
-		}
+// Scale scales the deployment and waits until it runs the target number of
+// ready pods.
+func (s *Executor) Scale(ctx context.Context, namespace, deploymentName string, targetReplicas int32, opts ActionOptions) (*entity.ActionResult, error) {
+	return s.ScaleWorkload(ctx, namespace, entity.DeploymentWorkload(deploymentName), targetReplicas, opts)
+}
+
+// ScaleWorkload scales a workload of any kind exposing the scale
+// subresource, e.g. a StatefulSet. Deployments are awaited until their pods
+// are ready; other kinds until their controller observed the target.
+func (s *Executor) ScaleWorkload(ctx context.Context, namespace string, workload entity.Workload, targetReplicas int32, opts ActionOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.Scale", append(objectAttributes(namespace, workload.Name),
+		attribute.String("k8s.workload.kind", workload.Kind),
+		attribute.Int("k8s.workload.target_replicas", int(targetReplicas)))...)
+	finish := s.track(entity.ActionScale)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
 
-		if deployment.Replicas == targetReplicas {
-			break
+	log.WithContext(ctx).Infof("Scale %s to replicas %d (dry run: %t)", workload, targetReplicas, opts.DryRun)
+	if targetReplicas < 0 {
+		return nil, &InvalidArgumentError{Argument: "replicas", Reason: "must not be negative"}
+	}
+	sc, err := s.kubeRepo.GetScale(ctx, namespace, workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
+	}
+	workload = sc.Workload
+	unlock, err := s.lock(namespace, strings.ToLower(workload.Kind), workload.Name, fmt.Sprintf("scale to %d replicas", targetReplicas), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
+	}
+	defer unlock()
+
+	// An autoscaler would silently revert the replica count.
+	hpa, err := s.kubeRepo.FindHorizontalPodAutoscaler(ctx, namespace, workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
+	}
+	var warnings []string
+	if hpa != nil {
+		if !opts.Force {
+			return nil, &ManagedByAutoscalerError{Workload: workload, Autoscaler: hpa}
 		}
+		warnings = append(warnings, fmt.Sprintf("replicas are managed by horizontal pod autoscaler %s (%d-%d) and may be reverted",
+			hpa.Name, hpa.MinReplicas, hpa.MaxReplicas))
+	}
+	budgetWarnings, err := s.checkScaleDown(ctx, namespace, sc, targetReplicas, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
+	}
+	warnings = append(warnings, budgetWarnings...)
+	action := &entity.Action{
+		Kind:           entity.ActionScale,
+		Cluster:        s.cluster,
+		Namespace:      namespace,
+		Name:           workload.Name,
+		Workload:       &workload,
+		Owner:          workload.Name,
+		Labels:         sc.Labels,
+		Replicas:       sc.Replicas,
+		TargetReplicas: targetReplicas,
+		DryRun:         opts.DryRun,
+		Force:          opts.Force,
+		Timeout:        opts.Timeout,
+		Approved:       opts.approved,
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
+	}
+	if pending != nil {
+		return pending, nil
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+	err = s.kubeRepo.Scale(ctx, namespace, workload, targetReplicas, opts.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
+	}
+	result = &entity.ActionResult{
+		DryRun: opts.DryRun,
+		Changes: []*entity.Change{
+			entity.NewChange("replicas", strconv.Itoa(int(sc.Replicas)), strconv.Itoa(int(targetReplicas))),
+		},
+		Warnings: warnings,
+	}
+	if opts.DryRun {
+		return result, nil
+	}
 
-		log.Infof("Wait until deployment %s end scalling", deploymentName)
-		time.Sleep(5 * time.Second)
+	err = s.waitForScale(ctx, namespace, workload, targetReplicas, s.waitTimeoutFor(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale: %w", err)
 	}
-	return nil
+	return result, nil
 }
 
-func (s *Executor) ListPodByDeployment(ctx context.Context, namespace, deploymentName string) ([]*entity.Pod, error) {
-	pods, err := s.kubeRepo.ListPodsByDeployment(ctx, namespace, deploymentName)
+func (s *Executor) GetScale(ctx context.Context, namespace string, workload entity.Workload) (sc *entity.Scale, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetScale", append(objectAttributes(namespace, workload.Name),
+		attribute.String("k8s.workload.kind", workload.Kind))...)
+	defer func() { endSpan(span, err) }()
+
+	sc, err = s.kubeRepo.GetScale(ctx, namespace, workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *Executor) ListPodByDeployment(ctx context.Context, namespace, deploymentName string, withMetrics bool) (pods []*entity.Pod, err error) {
+	ctx, span := startSpan(ctx, "Executor.ListPodByDeployment", objectAttributes(namespace, deploymentName)...)
+	defer func() { endSpan(span, err) }()
+
+	pods, err = s.kubeRepo.ListPodsByDeployment(ctx, namespace, deploymentName, withMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list pods by deployment: %w", err)
 	}
@@ -79,56 +280,136 @@ func (s *Executor) ListPodByDeployment(ctx context.Context, namespace, deploymen
 	return pods, nil
 }
 
-func (s *Executor) GetPodByName(ctx context.Context, namespace string, podName string) (*entity.Pod, error) {
-	pod, err := s.kubeRepo.GetPodByName(ctx, namespace, podName)
+func (s *Executor) GetPodByName(ctx context.Context, namespace string, podName string) (pod *entity.Pod, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetPodByName", objectAttributes(namespace, podName)...)
+	defer func() { endSpan(span, err) }()
+
+	pod, err = s.kubeRepo.GetPodByName(ctx, namespace, podName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pod by name: %w", err)
 	}
 	containers, err := s.kubeRepo.GetPodContainers(ctx, namespace, podName)
 	if err != nil {
-		log.Errorf("failed to get pod metrics: %w", err)
+		log.WithContext(ctx).Errorf("failed to get pod metrics: %v", err)
 	}
 	pod.Containers = containers
+	pod.DisruptionsAllowed = s.disruptionsAllowed(ctx, namespace, pod.Labels)
 	return pod, nil
 }
 
-func (s *Executor) GetDeploymentByName(ctx context.Context, namespace, deploymentName string) (*entity.Deployment, error) {
-	deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+func (s *Executor) GetDeploymentByName(ctx context.Context, namespace, deploymentName string) (deployment *entity.Deployment, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetDeploymentByName", objectAttributes(namespace, deploymentName)...)
+	defer func() { endSpan(span, err) }()
+
+	deployment, err = s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get deployment: %w", err)
 	}
+	deployment.DisruptionsAllowed = s.disruptionsAllowed(ctx, namespace, deployment.PodLabels)
 	return deployment, nil
 }
 
-func (s *Executor) GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error) {
-	logs, err := s.kubeRepo.GetPodLogs(ctx, namespace, podName, containerName, tailLines)
+func (s *Executor) GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (logs string, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetPodLogs", objectAttributes(namespace, podName)...)
+	defer func() { endSpan(span, err) }()
+
+	logs, err = s.kubeRepo.GetPodLogs(ctx, namespace, podName, containerName, tailLines)
 	if err != nil {
 		return "", fmt.Errorf("failed to get pod logs: %w", err)
 	}
 	return logs, nil
 }
 
-func (s *Executor) DescribePod(ctx context.Context, namespace, podName string) (string, error) {
-	desc, err := s.kubeRepo.DescribePod(ctx, namespace, podName)
+func (s *Executor) DescribePod(ctx context.Context, namespace, podName string) (desc string, err error) {
+	ctx, span := startSpan(ctx, "Executor.DescribePod", objectAttributes(namespace, podName)...)
+	defer func() { endSpan(span, err) }()
+
+	desc, err = s.kubeRepo.DescribePod(ctx, namespace, podName)
 	if err != nil {
 		return "", fmt.Errorf("failed to describe pod: %w", err)
 	}
 	return desc, nil
 }
 
-func (s *Executor) DescribeDeployment(ctx context.Context, namespace, deploymentName string) (string, error) {
-	desc, err := s.kubeRepo.DescribeDeployment(ctx, namespace, deploymentName)
+func (s *Executor) DescribeDeployment(ctx context.Context, namespace, deploymentName string) (desc string, err error) {
+	ctx, span := startSpan(ctx, "Executor.DescribeDeployment", objectAttributes(namespace, deploymentName)...)
+	defer func() { endSpan(span, err) }()
+
+	desc, err = s.kubeRepo.DescribeDeployment(ctx, namespace, deploymentName)
 	if err != nil {
 		return "", fmt.Errorf("failed to describe deployment: %w", err)
 	}
 	return desc, nil
 }
 
-func (s *Executor) Rollback(ctx context.Context, namespace, deploymentName string) error {
-	log.Infof("Rollback deployment %s", deploymentName)
-	err := s.kubeRepo.Rollback(ctx, namespace, deploymentName)
+func (s *Executor) Rollback(ctx context.Context, namespace, deploymentName string, opts ActionOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.Rollback", objectAttributes(namespace, deploymentName)...)
+	finish := s.track(entity.ActionRollback)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
+
+	log.WithContext(ctx).Infof("Rollback deployment %s (dry run: %t)", deploymentName, opts.DryRun)
+	unlock, err := s.lock(namespace, "deployment", deploymentName, "rollback", opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rollback: %w", err)
+	}
+	defer unlock()
+
+	deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rollback: %w", err)
+	}
+	action := &entity.Action{
+		Kind:      entity.ActionRollback,
+		Cluster:   s.cluster,
+		Namespace: namespace,
+		Name:      deploymentName,
+		Owner:     deploymentName,
+		Labels:    deployment.Labels,
+		Replicas:  deployment.Replicas,
+		DryRun:    opts.DryRun,
+		Approved:  opts.approved,
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rollback: %w", err)
+	}
+	if pending != nil {
+		return pending, nil
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+	changes, err := s.kubeRepo.Rollback(ctx, namespace, deploymentName, opts.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rollback: %w", err)
+	}
+	return &entity.ActionResult{DryRun: opts.DryRun, Changes: changes}, nil
+}
+
+// ServerVersion reports the version of the cluster, which also proves the
+// API server is reachable.
+func (s *Executor) ServerVersion(ctx context.Context) (version string, err error) {
+	ctx, span := startSpan(ctx, "Executor.ServerVersion")
+	defer func() { endSpan(span, err) }()
+
+	version, err = s.kubeRepo.ServerVersion(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to rollback: %w", err)
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	return version, nil
+}
+
+// CheckMetricsAPI reports whether container usage can be read from the cluster.
+func (s *Executor) CheckMetricsAPI(ctx context.Context) (err error) {
+	ctx, span := startSpan(ctx, "Executor.CheckMetricsAPI")
+	defer func() { endSpan(span, err) }()
+
+	if err := s.kubeRepo.CheckMetricsAPI(ctx); err != nil {
+		return fmt.Errorf("metrics API unavailable: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+// checkListOptions rejects options the Kubernetes API would not catch.
+func checkListOptions(opts entity.ListOptions) error {
+	if opts.Limit < 0 {
+		return &InvalidArgumentError{Argument: "limit", Reason: "must not be negative"}
+	}
+	switch strings.TrimPrefix(opts.SortBy, "-") {
+	case "", "name", "age":
+	default:
+		return &InvalidArgumentError{Argument: "sortBy", Reason: `must be "name" or "age", optionally prefixed with "-"`}
+	}
+	return nil
+}
+
+func (s *Executor) ListNamespaces(ctx context.Context, opts entity.ListOptions) (namespaces *entity.List[*entity.Namespace], err error) {
+	ctx, span := startSpan(ctx, "Executor.ListNamespaces")
+	defer func() { endSpan(span, err) }()
+
+	if err := checkListOptions(opts); err != nil {
+		return nil, err
+	}
+	namespaces, err = s.kubeRepo.ListNamespaces(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	return namespaces, nil
+}
+
+func (s *Executor) ListNodes(ctx context.Context, opts entity.ListOptions) (nodes *entity.List[*entity.Node], err error) {
+	ctx, span := startSpan(ctx, "Executor.ListNodes")
+	defer func() { endSpan(span, err) }()
+
+	if err := checkListOptions(opts); err != nil {
+		return nil, err
+	}
+	nodes, err = s.kubeRepo.ListNodes(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	return nodes, nil
+}
+
+func (s *Executor) ListPods(ctx context.Context, namespace string, opts entity.ListOptions) (pods *entity.List[*entity.Pod], err error) {
+	ctx, span := startSpan(ctx, "Executor.ListPods", namespaceAttributes(namespace)...)
+	defer func() { endSpan(span, err) }()
+
+	if err := checkListOptions(opts); err != nil {
+		return nil, err
+	}
+	pods, err = s.kubeRepo.ListPods(ctx, namespace, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	return pods, nil
+}
+
+func (s *Executor) ListDeployments(ctx context.Context, namespace string, opts entity.ListOptions) (deployments *entity.List[*entity.Deployment], err error) {
+	ctx, span := startSpan(ctx, "Executor.ListDeployments", namespaceAttributes(namespace)...)
+	defer func() { endSpan(span, err) }()
+
+	if err := checkListOptions(opts); err != nil {
+		return nil, err
+	}
+	deployments, err = s.kubeRepo.ListDeployments(ctx, namespace, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+func (s *Executor) ListStatefulSets(ctx context.Context, namespace string, opts entity.ListOptions) (statefulSets *entity.List[*entity.StatefulSet], err error) {
+	ctx, span := startSpan(ctx, "Executor.ListStatefulSets", namespaceAttributes(namespace)...)
+	defer func() { endSpan(span, err) }()
+
+	if err := checkListOptions(opts); err != nil {
+		return nil, err
+	}
+	statefulSets, err = s.kubeRepo.ListStatefulSets(ctx, namespace, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	return statefulSets, nil
+}
+
+func (s *Executor) ListServices(ctx context.Context, namespace string, opts entity.ListOptions) (services *entity.List[*entity.Service], err error) {
+	ctx, span := startSpan(ctx, "Executor.ListServices", namespaceAttributes(namespace)...)
+	defer func() { endSpan(span, err) }()
+
+	if err := checkListOptions(opts); err != nil {
+		return nil, err
+	}
+	services, err = s.kubeRepo.ListServices(ctx, namespace, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	return services, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// lockManager serializes mutations of the same Kubernetes object within the
+// process. Locks are not queued: a busy resource is reported to the caller.
+type lockManager struct {
+	mu   sync.Mutex
+	held map[string]*lockHolder
+}
+
+type lockHolder struct {
+	operation string
+	since     time.Time
+}
+
+func newLockManager() *lockManager {
+	return &lockManager{held: make(map[string]*lockHolder)}
+}
+
+// tryLock acquires the lock of namespace/kind/name for operation and returns
+// the function releasing it, or a *ResourceBusyError if the lock is held.
+func (m *lockManager) tryLock(namespace, kind, name, operation string) (func(), error) {
+	key := namespace + "/" + kind + "/" + name
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if holder, ok := m.held[key]; ok {
+		return nil, &ResourceBusyError{
+			Resource:  key,
+			Operation: holder.operation,
+			Since:     holder.since,
+		}
+	}
+
+	holder := &lockHolder{operation: operation, since: time.Now()}
+	m.held[key] = holder
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.held[key] == holder {
+			delete(m.held, key)
+		}
+	}, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+// Metrics receives measurements of executor actions.
+type Metrics interface {
+	ActionStarted(action string)
+	ActionFinished(action, outcome string, duration time.Duration)
+}
+
+func WithMetrics(metrics Metrics) Option {
+	return func(s *Executor) {
+		s.metrics = metrics
+	}
+}
+
+// track reports the start of an action and returns the function reporting
+// its outcome.
+func (s *Executor) track(action entity.ActionKind) func(*entity.ActionResult, error) {
+	if s.metrics == nil {
+		return func(*entity.ActionResult, error) {}
+	}
+	start := time.Now()
+	s.metrics.ActionStarted(string(action))
+	return func(result *entity.ActionResult, err error) {
+		s.metrics.ActionFinished(string(action), actionOutcome(result, err), time.Since(start))
+	}
+}
+
+func actionOutcome(result *entity.ActionResult, err error) string {
+	switch {
+	case errors.Is(err, ErrPolicyViolation):
+		return "denied"
+	case errors.Is(err, ErrResourceBusy):
+		return "busy"
+	case errors.Is(err, ErrPodNotFound), errors.Is(err, ErrDeploymentNotFound), errors.Is(err, ErrNodeNotFound),
+		errors.Is(err, ErrAutoscalerNotFound), errors.Is(err, ErrWorkloadNotFound):
+		return "not_found"
+	case errors.Is(err, ErrEvictionBlocked):
+		return "blocked"
+	case errors.Is(err, ErrTimeout):
+		return "timeout"
+	case err != nil:
+		return "error"
+	case result.Approval != nil:
+		return "pending_approval"
+	case result.DryRun:
+		return "dry_run"
+	default:
+		return "success"
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func nodeAttributes(nodeName string) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("k8s.node.name", nodeName)}
+}
+
+func (s *Executor) GetNode(ctx context.Context, nodeName string) (node *entity.Node, err error) {
+	ctx, span := startSpan(ctx, "Executor.GetNode", nodeAttributes(nodeName)...)
+	defer func() { endSpan(span, err) }()
+
+	node, err = s.kubeRepo.GetNode(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	return node, nil
+}
+
+// nodeAction looks up the node and returns the action to authorize.
+func (s *Executor) nodeAction(ctx context.Context, kind entity.ActionKind, nodeName string, opts ActionOptions) (*entity.Node, *entity.Action, error) {
+	node, err := s.kubeRepo.GetNode(ctx, nodeName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return node, &entity.Action{
+		Kind:        kind,
+		Cluster:     s.cluster,
+		Name:        nodeName,
+		Labels:      node.Labels,
+		DryRun:      opts.DryRun,
+		GracePeriod: opts.GracePeriod,
+		Timeout:     opts.Timeout,
+		Force:       opts.Force,
+		Approved:    opts.approved,
+	}, nil
+}
+
+// Cordon marks the node unschedulable, so no new pods are placed on it.
+func (s *Executor) Cordon(ctx context.Context, nodeName string, opts ActionOptions) (*entity.ActionResult, error) {
+	return s.setUnschedulable(ctx, entity.ActionCordon, nodeName, true, opts)
+}
+
+// Uncordon makes the node schedulable again.
+func (s *Executor) Uncordon(ctx context.Context, nodeName string, opts ActionOptions) (*entity.ActionResult, error) {
+	return s.setUnschedulable(ctx, entity.ActionUncordon, nodeName, false, opts)
+}
+
+func (s *Executor) setUnschedulable(ctx context.Context, kind entity.ActionKind, nodeName string, unschedulable bool, opts ActionOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.SetUnschedulable", append(nodeAttributes(nodeName),
+		attribute.Bool("k8s.node.unschedulable", unschedulable))...)
+	finish := s.track(kind)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
+
+	log.WithContext(ctx).Infof("Set node %s unschedulable: %t (dry run: %t)", nodeName, unschedulable, opts.DryRun)
+	unlock, err := s.lock("", "node", nodeName, string(kind), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	node, action, err := s.nodeAction(ctx, kind, nodeName, opts)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil || pending != nil {
+		return pending, err
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+	result = &entity.ActionResult{DryRun: opts.DryRun}
+	if node.Unschedulable == unschedulable {
+		return result, nil
+	}
+	if err := s.kubeRepo.SetUnschedulable(ctx, nodeName, unschedulable, opts.DryRun); err != nil {
+		return nil, err
+	}
+	result.Changes = append(result.Changes,
+		entity.NewChange("unschedulable", strconv.FormatBool(node.Unschedulable), strconv.FormatBool(unschedulable)))
+	return result, nil
+}
+
+// Drain cordons the node and evicts its pods, except those of DaemonSets,
+// static pods and completed pods. Evictions honour PodDisruptionBudgets:
+// a blocked eviction is retried until the wait timeout, after which the pod
+// is reported as failed. Pods without a controller would not be recreated,
+// so they fail the drain unless it is forced. When some pods are not
+// evicted, the result is returned along with the error.
+func (s *Executor) Drain(ctx context.Context, nodeName string, opts ActionOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.Drain", nodeAttributes(nodeName)...)
+	finish := s.track(entity.ActionDrain)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
+
+	log.WithContext(ctx).Infof("Drain node %s (dry run: %t)", nodeName, opts.DryRun)
+	if opts.GracePeriod != nil && *opts.GracePeriod < 0 {
+		return nil, &InvalidArgumentError{Argument: "gracePeriod", Reason: "must not be negative"}
+	}
+	unlock, err := s.lock("", "node", nodeName, "drain", opts)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	node, action, err := s.nodeAction(ctx, entity.ActionDrain, nodeName, opts)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil || pending != nil {
+		return pending, err
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+
+	result = &entity.ActionResult{DryRun: opts.DryRun}
+	if !node.Unschedulable {
+		if err := s.kubeRepo.SetUnschedulable(ctx, nodeName, true, opts.DryRun); err != nil {
+			return nil, err
+		}
+		result.Changes = append(result.Changes, entity.NewChange("unschedulable", "false", "true"))
+	}
+	pods, err := s.kubeRepo.ListPodsOnNode(ctx, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := s.waitTimeoutFor(opts)
+	evictCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result.Pods = make([]*entity.PodResult, len(pods))
+	var evictions []int
+	for i, pod := range pods {
+		if reason := drainSkipReason(pod); reason != "" {
+			result.Pods[i] = &entity.PodResult{Namespace: pod.Namespace, Name: pod.Name, Outcome: entity.PodSkipped, Reason: reason}
+			continue
+		}
+		if pod.ControllerUID == "" {
+			if !opts.Force {
+				result.Pods[i] = &entity.PodResult{Namespace: pod.Namespace, Name: pod.Name, Outcome: entity.PodFailed,
+					Reason: "not managed by a controller, so it would not be recreated; force the drain to evict it"}
+				continue
+			}
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("pod %s/%s is not managed by a controller and will not be recreated", pod.Namespace, pod.Name))
+		}
+		evictions = append(evictions, i)
+	}
+
+	var wg sync.WaitGroup
+	var done atomic.Int32
+	for _, i := range evictions {
+		pod := pods[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := s.evict(evictCtx, pod, timeout, opts)
+			result.Pods[i] = res
+			log.WithContext(ctx).Infof("Drain node %s: pod %s/%s %s (%d/%d)",
+				nodeName, pod.Namespace, pod.Name, res.Outcome, done.Add(1), len(evictions))
+		}()
+	}
+	wg.Wait()
+
+	var failed []*entity.PodResult
+	for _, pod := range result.Pods {
+		if pod.Outcome == entity.PodFailed {
+			failed = append(failed, pod)
+		}
+	}
+	if len(failed) > 0 {
+		return result, &DrainIncompleteError{Node: nodeName, Failed: failed, Pods: result.Pods}
+	}
+	return result, nil
+}
+
+// drainSkipReason tells why a drain leaves the pod on the node, if it does.
+func drainSkipReason(pod *entity.Pod) string {
+	switch {
+	case pod.OwnerKind == "DaemonSet":
+		return fmt.Sprintf("managed by DaemonSet %s", pod.Owner)
+	case pod.Mirror:
+		return "static pod"
+	case pod.Status == "Succeeded" || pod.Status == "Failed":
+		return "pod completed"
+	}
+	return ""
+}
+
+// evict evicts the pod, retrying while a disruption budget blocks it, and
+// waits for the pod to be gone.
+func (s *Executor) evict(ctx context.Context, pod *entity.Pod, timeout time.Duration, opts ActionOptions) *entity.PodResult {
+	start := time.Now()
+	res := &entity.PodResult{Namespace: pod.Namespace, Name: pod.Name, Outcome: entity.PodEvicted}
+	defer func() { res.Duration = time.Since(start) }()
+
+	for {
+		err := s.kubeRepo.Evict(ctx, pod.Namespace, pod.Name, opts.GracePeriod, opts.DryRun)
+		if errors.Is(err, ErrPodNotFound) {
+			return res
+		}
+		if err == nil {
+			break
+		}
+		// A dry run reports blocked evictions instead of waiting them out.
+		if !errors.Is(err, ErrEvictionBlocked) || opts.DryRun {
+			res.Outcome, res.Reason = entity.PodFailed, err.Error()
+			return res
+		}
+		select {
+		case <-ctx.Done():
+			res.Outcome, res.Reason = entity.PodFailed, err.Error()
+			return res
+		case <-time.After(s.pollInterval):
+		}
+	}
+	if opts.DryRun {
+		return res
+	}
+	if err := s.waitForPodDeletion(ctx, pod, timeout); err != nil {
+		res.Outcome, res.Reason = entity.PodFailed, err.Error()
+	}
+	return res
+}
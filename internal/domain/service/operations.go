@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+)
+
+// WithOperations persists every mutation in repo while it runs.
+func WithOperations(repo entity.OperationRepository) Option {
+	return func(s *Executor) {
+		s.operations = repo
+	}
+}
+
+// begin records the start of a mutation and returns the function recording
+// its end. Shutdown waits for every begun operation to end.
+func (s *Executor) begin(ctx context.Context, action *entity.Action) func(error) {
+	operation := &entity.Operation{
+		ID:          uuid.NewString(),
+		Action:      action,
+		Status:      entity.OperationRunning,
+		RequestedBy: PrincipalFromContext(ctx),
+		StartedAt:   time.Now(),
+	}
+	s.operationMu.Lock()
+	s.running[operation.ID] = operation
+	s.saveOperation(ctx, operation)
+	s.operationMu.Unlock()
+
+	return func(err error) {
+		s.operationMu.Lock()
+		defer s.operationMu.Unlock()
+
+		delete(s.running, operation.ID)
+		if len(s.running) == 0 && s.drained != nil {
+			close(s.drained)
+			s.drained = nil
+		}
+		operation.Status = entity.OperationSucceeded
+		operation.FinishedAt = time.Now()
+		if err != nil {
+			operation.Status = entity.OperationFailed
+			operation.Error = err.Error()
+		}
+		s.saveOperation(ctx, operation)
+	}
+}
+
+// saveOperation must be called with operationMu held. The operation is
+// tracked in memory anyway, so a failure to persist it is only logged.
+func (s *Executor) saveOperation(ctx context.Context, operation *entity.Operation) {
+	if s.operations == nil {
+		return
+	}
+	if err := s.operations.Save(ctx, operation); err != nil {
+		log.WithContext(ctx).Errorf("failed to save operation %s: %v", operation.ID, err)
+	}
+}
+
+// Shutdown waits for running operations to end until ctx is done, then
+// marks the remaining ones as interrupted.
+func (s *Executor) Shutdown(ctx context.Context) error {
+	s.operationMu.Lock()
+	if len(s.running) == 0 {
+		s.operationMu.Unlock()
+		return nil
+	}
+	if s.drained == nil {
+		s.drained = make(chan struct{})
+	}
+	drained := s.drained
+	s.operationMu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.operationMu.Lock()
+	defer s.operationMu.Unlock()
+
+	var errs []error
+	for _, operation := range s.running {
+		log.Warnf("%s of %s/%s interrupted by shutdown (operation %s)",
+			operation.Action.Kind, operation.Action.Namespace, operation.Action.Name, operation.ID)
+		interrupted := *operation
+		interrupted.Status = entity.OperationInterrupted
+		interrupted.FinishedAt = time.Now()
+		interrupted.Error = "interrupted by executor shutdown"
+		if s.operations == nil {
+			continue
+		}
+		if err := s.operations.Save(context.WithoutCancel(ctx), &interrupted); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save operation %s: %w", operation.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
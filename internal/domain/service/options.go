@@ -0,0 +1,45 @@
+package service
+
+import "time"
+
+// ActionOptions tunes how a mutating executor action is carried out.
+type ActionOptions struct {
+	// DryRun runs validation and lookups and submits the change with
+	// server-side dry run, so nothing is persisted in the cluster.
+	DryRun bool
+	// Timeout bounds the wait for the cluster to complete the action. It is
+	// capped by the configured wait timeout, which also applies when zero.
+	Timeout time.Duration
+	// RestartMode overrides the configured restart mode when set.
+	RestartMode RestartMode
+	// GracePeriod overrides the termination grace period of the pods the
+	// action deletes; nil keeps their own.
+	GracePeriod *time.Duration
+	// Force carries out the action despite safeguards refusing it, such as
+	// an autoscaler owning the replica count, with a warning instead.
+	Force bool
+
+	// approved is set when the action is executed on behalf of an approval.
+	approved bool
+}
+
+// RestartMode is how Restart removes a pod for its controller to replace it.
+type RestartMode string
+
+const (
+	// RestartEvict evicts the pod, which honours its PodDisruptionBudgets.
+	RestartEvict RestartMode = "evict"
+	// RestartDelete deletes the pod, bypassing its PodDisruptionBudgets.
+	RestartDelete RestartMode = "delete"
+)
+
+func (m RestartMode) Valid() bool {
+	return m == RestartEvict || m == RestartDelete
+}
+
+// WithRestartMode sets the restart mode used when a request does not choose one.
+func WithRestartMode(mode RestartMode) Option {
+	return func(s *Executor) {
+		s.restartMode = mode
+	}
+}
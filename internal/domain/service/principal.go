@@ -0,0 +1,16 @@
+package service
+
+import "context"
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the identity of the caller.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller identity, or "" if it is unknown.
+func PrincipalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(string)
+	return principal
+}
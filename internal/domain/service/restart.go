@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RollingRestartOptions tunes a rolling restart.
+type RollingRestartOptions struct {
+	ActionOptions
+	// WaveSize is how many pods are restarted at once; at least 1.
+	WaveSize int
+	// MaxFailures is how many pods may fail to be replaced before the
+	// remaining waves are cancelled.
+	MaxFailures int
+}
+
+// restartModeFor validates the restart settings of opts and returns the mode
+// to restart with.
+func (s *Executor) restartModeFor(opts ActionOptions) (RestartMode, error) {
+	mode := opts.RestartMode
+	if mode == "" {
+		mode = s.restartMode
+	}
+	if !mode.Valid() {
+		return mode, &InvalidArgumentError{Argument: "mode", Reason: `must be "evict" or "delete"`}
+	}
+	if opts.GracePeriod != nil && *opts.GracePeriod < 0 {
+		return mode, &InvalidArgumentError{Argument: "gracePeriod", Reason: "must not be negative"}
+	}
+	return mode, nil
+}
+
+// replacementClaims hands each new pod of a controller to a single removed
+// pod, so pods restarted together do not share a replacement.
+type replacementClaims struct {
+	mu sync.Mutex
+	// byOriginal maps the UID of a removed pod to the UID of its replacement,
+	// and claimed holds the UIDs of the replacements.
+	byOriginal map[string]string
+	claimed    map[string]bool
+}
+
+func newReplacementClaims() *replacementClaims {
+	return &replacementClaims{byOriginal: make(map[string]string), claimed: make(map[string]bool)}
+}
+
+// claim reports whether candidate replaces original. The first candidate no
+// other pod claimed becomes the replacement of original for good.
+func (c *replacementClaims) claim(original, candidate *entity.Pod) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if uid, ok := c.byOriginal[original.UID]; ok {
+		return uid == candidate.UID
+	}
+	if c.claimed[candidate.UID] {
+		return false
+	}
+	c.byOriginal[original.UID] = candidate.UID
+	c.claimed[candidate.UID] = true
+	return true
+}
+
+// replacePod removes the pod and waits until its controller replaced it with
+// a ready pod, within the wait timeout. Evictions blocked by a disruption
+// budget fail at once unless retryBlocked, in which case they are retried
+// until the timeout. Nothing is awaited in a dry run or for a bare pod.
+//
+// The replacement is a pod of the controller created no earlier than the
+// removal, other than the pods it ran before, and not claimed by another pod
+// removed with the same claims.
+func (s *Executor) replacePod(ctx context.Context, pod *entity.Pod, mode RestartMode, opts ActionOptions, retryBlocked bool, claims *replacementClaims) (*entity.Replacement, error) {
+	deadline := time.Now().Add(s.waitTimeoutFor(opts))
+	var siblings []string
+	if !opts.DryRun && pod.ControllerUID != "" {
+		var err error
+		if siblings, err = s.kubeRepo.ListSiblingPods(ctx, pod); err != nil {
+			return nil, err
+		}
+	}
+	var removedAt time.Time
+	for {
+		var err error
+		removedAt = time.Now()
+		if mode == RestartEvict {
+			err = s.kubeRepo.Evict(ctx, pod.Namespace, pod.Name, opts.GracePeriod, opts.DryRun)
+		} else {
+			err = s.kubeRepo.Delete(ctx, pod.Namespace, pod.Name, opts.GracePeriod, opts.DryRun)
+		}
+		if err == nil {
+			break
+		}
+		if !retryBlocked || opts.DryRun || !errors.Is(err, ErrEvictionBlocked) {
+			return nil, err
+		}
+		timer := time.NewTimer(min(s.pollInterval, time.Until(deadline)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+	}
+	if opts.DryRun {
+		return nil, nil
+	}
+
+	if err := s.waitForPodDeletion(ctx, pod, time.Until(deadline)); err != nil {
+		return nil, err
+	}
+	if pod.ControllerUID == "" {
+		// Nothing replaces a bare pod.
+		return nil, nil
+	}
+	// Creation times are truncated to the second by the API server.
+	createdAfter := removedAt.Truncate(time.Second)
+	accept := func(candidate *entity.Pod) bool {
+		return !slices.Contains(siblings, candidate.UID) && !candidate.CreatedAt.Before(createdAfter) &&
+			claims.claim(pod, candidate)
+	}
+	replacement, err := s.waitForReplacement(ctx, pod, accept, time.Until(deadline))
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Replacement{
+		Name:        replacement.Name,
+		Node:        replacement.Node,
+		TimeToReady: time.Since(removedAt),
+	}, nil
+}
+
+// RollingRestart restarts every pod of the deployment without changing its
+// template, in waves of opts.WaveSize pods. Each wave waits until its pods
+// were replaced by ready pods. Like Restart, a ready pod whose budgets allow
+// no disruption is refused unless forced; it then counts as failed. A wave
+// takes no more pods than the budgets allow to disrupt at once. Once
+// more than opts.MaxFailures pods failed, the remaining pods are skipped and
+// the result is returned along with the error.
+func (s *Executor) RollingRestart(ctx context.Context, namespace, deploymentName string, opts RollingRestartOptions) (result *entity.ActionResult, err error) {
+	ctx, span := startSpan(ctx, "Executor.RollingRestart", append(objectAttributes(namespace, deploymentName),
+		attribute.Int("wave_size", opts.WaveSize))...)
+	finish := s.track(entity.ActionRollingRestart)
+	defer func() {
+		endSpan(span, err)
+		finish(result, err)
+	}()
+
+	mode, err := s.restartModeFor(opts.ActionOptions)
+	if err != nil {
+		return nil, err
+	}
+	if opts.WaveSize < 1 {
+		return nil, &InvalidArgumentError{Argument: "waveSize", Reason: "must be positive"}
+	}
+	if opts.MaxFailures < 0 {
+		return nil, &InvalidArgumentError{Argument: "maxFailures", Reason: "must not be negative"}
+	}
+	log.WithContext(ctx).Infof("Rolling restart of deployment %s by %s in waves of %d (dry run: %t)",
+		deploymentName, mode, opts.WaveSize, opts.DryRun)
+	unlock, err := s.lock(namespace, "deployment", deploymentName, "rolling restart", opts.ActionOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, deploymentName)
+	if err != nil {
+		return nil, err
+	}
+	action := &entity.Action{
+		Kind:        entity.ActionRollingRestart,
+		Cluster:     s.cluster,
+		Namespace:   namespace,
+		Name:        deploymentName,
+		Owner:       deploymentName,
+		Labels:      deployment.Labels,
+		Replicas:    deployment.Replicas,
+		WaveSize:    opts.WaveSize,
+		MaxFailures: opts.MaxFailures,
+		DryRun:      opts.DryRun,
+		Force:       opts.Force,
+		RestartMode: string(mode),
+		GracePeriod: opts.GracePeriod,
+		Timeout:     opts.Timeout,
+		Approved:    opts.approved,
+	}
+	pending, err := s.authorize(ctx, action)
+	if err != nil || pending != nil {
+		return pending, err
+	}
+	if !opts.DryRun {
+		end := s.begin(ctx, action)
+		defer func() { end(err) }()
+	}
+
+	pods, err := s.kubeRepo.ListPodsByDeployment(ctx, namespace, deploymentName, false)
+	if err != nil {
+		return nil, err
+	}
+	result = &entity.ActionResult{DryRun: opts.DryRun, Pods: make([]*entity.PodResult, len(pods))}
+	remaining := make([]int, len(pods))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	claims := newReplacementClaims()
+	var failed []*entity.PodResult
+	for len(remaining) > 0 {
+		if len(failed) > opts.MaxFailures {
+			for _, i := range remaining {
+				result.Pods[i] = &entity.PodResult{Namespace: namespace, Name: pods[i].Name, Outcome: entity.PodSkipped,
+					Reason: fmt.Sprintf("aborted after %d failures", len(failed))}
+			}
+			break
+		}
+		log.WithContext(ctx).Infof("Rolling restart of deployment %s: wave of up to %d pods, %d of %d left",
+			deploymentName, opts.WaveSize, len(remaining), len(pods))
+
+		// The pods of a wave are admitted against the disruptions their
+		// budgets allow at its start; the pods past them wait for a later wave.
+		admitted := make(map[string]int32)
+		var wg sync.WaitGroup
+		started, done := 0, 0
+		for _, i := range remaining {
+			if started == opts.WaveSize {
+				break
+			}
+			warnings, err := s.checkPodDisruption(ctx, pods[i], opts.ActionOptions, admitted)
+			if errors.Is(err, ErrDisruptionBudget) && started > 0 {
+				break
+			}
+			done++
+			if err != nil {
+				result.Pods[i] = &entity.PodResult{Namespace: namespace, Name: pods[i].Name, Outcome: entity.PodFailed, Reason: err.Error()}
+				continue
+			}
+			for _, warning := range warnings {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("pod %s: %s", pods[i].Name, warning))
+			}
+			started++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result.Pods[i] = s.restartInWave(ctx, pods[i], mode, opts.ActionOptions, claims)
+			}()
+		}
+		wg.Wait()
+		for _, i := range remaining[:done] {
+			if result.Pods[i].Outcome == entity.PodFailed {
+				failed = append(failed, result.Pods[i])
+			}
+		}
+		remaining = remaining[done:]
+	}
+	for _, pod := range result.Pods {
+		if pod.Outcome == entity.PodRestarted {
+			result.Changes = append(result.Changes, entity.NewChange("pod", pod.Name, ""))
+		}
+	}
+	if len(failed) > opts.MaxFailures {
+		return result, &RollingRestartAbortedError{Deployment: deploymentName, MaxFailures: opts.MaxFailures, Failed: failed, Pods: result.Pods}
+	}
+	return result, nil
+}
+
+func (s *Executor) restartInWave(ctx context.Context, pod *entity.Pod, mode RestartMode, opts ActionOptions, claims *replacementClaims) *entity.PodResult {
+	start := time.Now()
+	res := &entity.PodResult{Namespace: pod.Namespace, Name: pod.Name, Outcome: entity.PodRestarted}
+	replacement, err := s.replacePod(ctx, pod, mode, opts, true, claims)
+	res.Duration = time.Since(start)
+	if err != nil {
+		res.Outcome, res.Reason = entity.PodFailed, err.Error()
+	}
+	res.Replacement = replacement
+	log.WithContext(ctx).Infof("Rolling restart: pod %s/%s %s", pod.Namespace, pod.Name, res.Outcome)
+	return res
+}
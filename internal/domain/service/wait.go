@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// waitTimeoutFor returns the time an action may wait for the cluster: the
+// requested timeout, capped by the configured one.
+func (s *Executor) waitTimeoutFor(opts ActionOptions) time.Duration {
+	if opts.Timeout > 0 && opts.Timeout < s.waitTimeout {
+		return opts.Timeout
+	}
+	return s.waitTimeout
+}
+
+// waitFor blocks until the cluster reaches the awaited state, ctx is done or
+// timeout elapses. It follows a watch and falls back to polling with
+// exponential backoff when the watch cannot be established or ends early. A
+// nil watch polls from the start.
+func (s *Executor) waitFor(ctx context.Context, operation string, timeout time.Duration,
+	watch func(context.Context) error, poll func(context.Context) (bool, error)) (err error) {
+	ctx, span := startSpan(ctx, "Executor.wait", attribute.String("operation", operation))
+	defer func() { endSpan(span, err) }()
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.WithContext(ctx).Infof("Wait for %s", operation)
+	if watch != nil {
+		err = watch(waitCtx)
+		if err == nil {
+			return nil
+		}
+		if waitCtx.Err() != nil {
+			return waitError(ctx, operation, timeout)
+		}
+		// A domain error is the verdict of the watch, not a failure to watch.
+		var domainErr *Error
+		if errors.As(err, &domainErr) {
+			return err
+		}
+		log.WithContext(ctx).Warnf("Watch for %s failed, falling back to polling: %v", operation, err)
+	}
+
+	delay := s.pollInterval
+	for attempt := 1; ; attempt++ {
+		pollCtx, pollSpan := startSpan(waitCtx, "Executor.wait.poll", attribute.Int("attempt", attempt))
+		done, err := poll(pollCtx)
+		endSpan(pollSpan, err)
+		if waitCtx.Err() != nil {
+			return waitError(ctx, operation, timeout)
+		}
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-waitCtx.Done():
+			timer.Stop()
+			return waitError(ctx, operation, timeout)
+		case <-timer.C:
+		}
+		delay = min(delay*2, s.maxPollInterval)
+	}
+}
+
+// waitError tells a wait cut short by the caller from one that timed out.
+func waitError(ctx context.Context, operation string, timeout time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return &TimeoutError{Operation: operation, Timeout: timeout}
+}
+
+func (s *Executor) waitForPodDeletion(ctx context.Context, pod *entity.Pod, timeout time.Duration) error {
+	return s.waitFor(ctx, fmt.Sprintf("deletion of pod %s", pod.Name), timeout,
+		func(ctx context.Context) error {
+			return s.kubeRepo.WaitForPodDeletion(ctx, pod.Namespace, pod.Name, pod.UID)
+		},
+		func(ctx context.Context) (bool, error) {
+			current, err := s.kubeRepo.GetPodByName(ctx, pod.Namespace, pod.Name)
+			if errors.Is(err, ErrPodNotFound) {
+				return true, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			return current.UID != pod.UID, nil
+		})
+}
+
+// waitForReplacement waits until the controller of the removed pod replaced
+// it with a ready pod, and fails early when the replacement is stuck. Only
+// the pods accept takes count as the replacement.
+func (s *Executor) waitForReplacement(ctx context.Context, original *entity.Pod, accept func(*entity.Pod) bool, timeout time.Duration) (*entity.Pod, error) {
+	ready := func(pod *entity.Pod) (bool, error) {
+		if reason := pod.FailureReason(); reason != "" {
+			return false, &ReplacementFailedError{Pod: pod.Name, Reason: reason}
+		}
+		return pod.Ready, nil
+	}
+	var replacement *entity.Pod
+	err := s.waitFor(ctx, fmt.Sprintf("replacement of pod %s to be ready", original.Name), timeout,
+		func(ctx context.Context) error {
+			pod, err := s.kubeRepo.WaitForReplacementPod(ctx, original, accept, ready)
+			replacement = pod
+			return err
+		},
+		func(ctx context.Context) (bool, error) {
+			pod, err := s.kubeRepo.GetReplacementPod(ctx, original, accept)
+			if errors.Is(err, ErrPodNotFound) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			replacement = pod
+			return ready(pod)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return replacement, nil
+}
+
+// waitForScale waits until the workload is scaled. Deployments are watched
+// until their pods are ready; other kinds only report through their scale
+// subresource how many pods their controller runs.
+func (s *Executor) waitForScale(ctx context.Context, namespace string, workload entity.Workload, replicas int32, timeout time.Duration) error {
+	operation := fmt.Sprintf("scaling of %s to %d replicas", workload, replicas)
+	if workload.IsDeployment() {
+		return s.waitFor(ctx, operation, timeout,
+			func(ctx context.Context) error {
+				return s.kubeRepo.WaitForReplicas(ctx, namespace, workload.Name, replicas)
+			},
+			func(ctx context.Context) (bool, error) {
+				deployment, err := s.kubeRepo.GetDeploymentByName(ctx, namespace, workload.Name)
+				if err != nil {
+					return false, err
+				}
+				return deployment.ScaledTo(replicas), nil
+			})
+	}
+	return s.waitFor(ctx, operation, timeout, nil,
+		func(ctx context.Context) (bool, error) {
+			sc, err := s.kubeRepo.GetScale(ctx, namespace, workload)
+			if err != nil {
+				return false, err
+			}
+			return sc.Replicas == replicas && sc.CurrentReplicas == replicas, nil
+		})
+}
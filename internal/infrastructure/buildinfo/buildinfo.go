@@ -0,0 +1,32 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Commit is the revision the binary was built from. It can be set with
+// -ldflags "-X github.com/inviewteam/fenrir.executor/internal/infrastructure/buildinfo.Commit=<sha>",
+// otherwise the VCS revision recorded by the go tool is used.
+var Commit string
+
+type Info struct {
+	Commit    string
+	GoVersion string
+}
+
+func Get() Info {
+	info := Info{Commit: Commit, GoVersion: runtime.Version()}
+	if info.Commit != "" {
+		return info
+	}
+	info.Commit = "unknown"
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			if setting.Key == "vcs.revision" {
+				info.Commit = setting.Value
+			}
+		}
+	}
+	return info
+}
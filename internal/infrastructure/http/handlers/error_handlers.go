@@ -1,11 +1,15 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+)
 
 func NotAllowedHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Method not allowed"
-		http.Error(w, errorMessage, http.StatusMethodNotAllowed)
+		problem.Write(w, r, http.StatusMethodNotAllowed, "method_not_allowed", errorMessage)
 	})
 
 }
@@ -13,6 +17,6 @@ func NotAllowedHandler() http.Handler {
 func NotFoundHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Page not found"
-		http.Error(w, errorMessage, http.StatusNotFound)
+		problem.Write(w, r, http.StatusNotFound, "route_not_found", errorMessage)
 	})
 }
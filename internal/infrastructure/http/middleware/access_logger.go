@@ -4,22 +4,66 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 )
 
 // Create a request logging middleware handler called Logger
 type Logger struct {
+	router  *mux.Router
 	handler http.Handler
+	quiet   map[string]bool
 }
 
 // ServeHTTP handles the request by passing it to the real handler and logging the request details
 func (l *Logger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if l.quiet[r.URL.Path] {
+		l.handler.ServeHTTP(w, r)
+		return
+	}
 	start := time.Now()
-	l.handler.ServeHTTP(w, r)
-	log.Infof("%s %s %v", r.Method, r.URL.Path, r.Header, time.Since(start))
+	cw := &countingWriter{ResponseWriter: w, status: http.StatusOK}
+	l.handler.ServeHTTP(cw, r)
+
+	route := ""
+	var match mux.RouteMatch
+	if l.router.Match(r, &match) && match.Route != nil {
+		route, _ = match.Route.GetPathTemplate()
+	}
+	log.WithContext(r.Context()).WithFields(log.Fields{
+		"method":      r.Method,
+		"route":       route,
+		"path":        r.URL.Path,
+		"status":      cw.status,
+		"bytes":       cw.bytes,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"remote_addr": r.RemoteAddr,
+	}).Info("request served")
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *countingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
 }
 
-// NewLogger constructs a new Logger middleware handler
-func NewLogger(handlerToWrap http.Handler) *Logger {
-	return &Logger{handlerToWrap}
+// NewLogger constructs a new Logger middleware handler resolving routes with router.
+// Requests to quietPaths, e.g. probes, are not logged.
+func NewLogger(router *mux.Router, handlerToWrap http.Handler, quietPaths ...string) *Logger {
+	quiet := make(map[string]bool, len(quietPaths))
+	for _, path := range quietPaths {
+		quiet[path] = true
+	}
+	return &Logger{router, handlerToWrap, quiet}
 }
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+// Idempotency replays the stored response of a mutating request when it is
+// repeated with the same Idempotency-Key header and payload. Reusing a key
+// with a different payload is rejected with 422.
+type Idempotency struct {
+	ttl     time.Duration
+	handler http.Handler
+
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	fingerprint [sha256.Size]byte
+	expires     time.Time
+	// done is closed once the first request has completed.
+	done   chan struct{}
+	status int
+	header http.Header
+	body   []byte
+}
+
+// ServeHTTP runs the first request for a key and replays its response for repeats
+func (i *Idempotency) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+		i.handler.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		problem.Write(w, r, http.StatusBadRequest, "invalid_body", "failed to read request body")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	fingerprint := sha256.Sum256(append([]byte(r.Method+" "+r.URL.RequestURI()+"\n"), body...))
+	// Keys are scoped to the caller so that clients cannot replay each other's responses.
+	key = service.PrincipalFromContext(r.Context()) + "\x00" + key
+
+	entry, first := i.acquire(key, fingerprint)
+	if entry.fingerprint != fingerprint {
+		log.WithContext(r.Context()).Infof("idempotency key reused with a different payload")
+		problem.Write(w, r, http.StatusUnprocessableEntity, "idempotency_key_reused", "idempotency key was already used with a different payload")
+		return
+	}
+
+	if !first {
+		select {
+		case <-entry.done:
+		case <-r.Context().Done():
+			// Without a reply the server would send an empty 200.
+			problem.Write(w, r, http.StatusConflict, "idempotent_request_in_progress", "request with the same idempotency key still in progress")
+			return
+		}
+		if entry.status == 0 {
+			// The first request failed and was forgotten; let the caller retry.
+			problem.Write(w, r, http.StatusConflict, "idempotent_request_failed", "request with the same idempotency key failed, retry")
+			return
+		}
+		for name, values := range entry.header {
+			w.Header()[name] = values
+		}
+		w.Header().Set(IdempotencyReplayedHeader, "true")
+		w.WriteHeader(entry.status)
+		w.Write(entry.body)
+		return
+	}
+
+	rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		i.complete(key, entry, rec)
+	}()
+	i.handler.ServeHTTP(rec, r)
+}
+
+// acquire returns the live entry for key, creating it when there is none.
+// first reports whether the caller created the entry and must fill it.
+func (i *Idempotency) acquire(key string, fingerprint [sha256.Size]byte) (*idempotencyEntry, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range i.entries {
+		if isClosed(e.done) && now.After(e.expires) {
+			delete(i.entries, k)
+		}
+	}
+	if entry, ok := i.entries[key]; ok {
+		return entry, false
+	}
+
+	entry := &idempotencyEntry{
+		fingerprint: fingerprint,
+		done:        make(chan struct{}),
+	}
+	i.entries[key] = entry
+	return entry, true
+}
+
+// complete stores the recorded response. Server errors are not stored, so a
+// retry with the same key executes the request again.
+func (i *Idempotency) complete(key string, entry *idempotencyEntry, rec *responseRecorder) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if rec.status >= http.StatusInternalServerError {
+		delete(i.entries, key)
+	} else {
+		entry.status = rec.status
+		entry.header = rec.Header().Clone()
+		entry.body = rec.body.Bytes()
+		entry.expires = time.Now().Add(i.ttl)
+	}
+	close(entry.done)
+}
+
+func isClosed(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// responseRecorder passes the response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// NewIdempotency constructs a new Idempotency middleware handler keeping responses for ttl
+func NewIdempotency(ttl time.Duration, handlerToWrap http.Handler) *Idempotency {
+	return &Idempotency{
+		ttl:     ttl,
+		handler: handlerToWrap,
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+)
+
+// countingHandler replies with the statuses in turn, the last one repeated,
+// and the number of the call as body.
+type countingHandler struct {
+	calls    atomic.Int32
+	statuses []int
+	// release, when set, holds every call until it is closed, and entered
+	// receives a value as each call starts.
+	release chan struct{}
+	entered chan struct{}
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := int(h.calls.Add(1))
+	if h.entered != nil {
+		h.entered <- struct{}{}
+	}
+	if h.release != nil {
+		<-h.release
+	}
+	status := http.StatusOK
+	if len(h.statuses) > 0 {
+		status = h.statuses[min(call, len(h.statuses))-1]
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(strings.Repeat("x", call)))
+}
+
+func newIdempotentRequest(ctx context.Context, principal, method, key, body string) *http.Request {
+	r := httptest.NewRequest(method, "/api/kubernetes/default/pods/web-0", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return r.WithContext(service.WithPrincipal(ctx, principal))
+}
+
+func problemCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var problem struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("reply is not a problem: %q", rec.Body.String())
+	}
+	return problem.Code
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	type request struct {
+		principal, method, key, body string
+	}
+	tests := []struct {
+		name     string
+		first    request
+		second   request
+		status   int
+		replayed bool
+		calls    int32
+	}{
+		{
+			name:     "repeat is replayed",
+			first:    request{"alice", http.MethodDelete, "k1", ""},
+			second:   request{"alice", http.MethodDelete, "k1", ""},
+			status:   http.StatusOK,
+			replayed: true,
+			calls:    1,
+		},
+		{
+			name:   "keys are scoped to the principal",
+			first:  request{"alice", http.MethodDelete, "k1", ""},
+			second: request{"bob", http.MethodDelete, "k1", ""},
+			status: http.StatusOK,
+			calls:  2,
+		},
+		{
+			name:   "reused key with another payload",
+			first:  request{"alice", http.MethodPut, "k1", `{"replicas":1}`},
+			second: request{"alice", http.MethodPut, "k1", `{"replicas":2}`},
+			status: http.StatusUnprocessableEntity,
+			calls:  1,
+		},
+		{
+			name:   "reused key with another method",
+			first:  request{"alice", http.MethodDelete, "k1", ""},
+			second: request{"alice", http.MethodPut, "k1", ""},
+			status: http.StatusUnprocessableEntity,
+			calls:  1,
+		},
+		{
+			name:   "no key",
+			first:  request{"alice", http.MethodDelete, "", ""},
+			second: request{"alice", http.MethodDelete, "", ""},
+			status: http.StatusOK,
+			calls:  2,
+		},
+		{
+			name:   "reads are not replayed",
+			first:  request{"alice", http.MethodGet, "k1", ""},
+			second: request{"alice", http.MethodGet, "k1", ""},
+			status: http.StatusOK,
+			calls:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &countingHandler{}
+			idempotency := NewIdempotency(time.Hour, handler)
+			ctx := context.Background()
+
+			first := httptest.NewRecorder()
+			idempotency.ServeHTTP(first, newIdempotentRequest(ctx, tt.first.principal, tt.first.method, tt.first.key, tt.first.body))
+			second := httptest.NewRecorder()
+			idempotency.ServeHTTP(second, newIdempotentRequest(ctx, tt.second.principal, tt.second.method, tt.second.key, tt.second.body))
+
+			if second.Code != tt.status {
+				t.Errorf("status = %d, want %d", second.Code, tt.status)
+			}
+			if replayed := second.Header().Get(IdempotencyReplayedHeader) == "true"; replayed != tt.replayed {
+				t.Errorf("replayed = %t, want %t", replayed, tt.replayed)
+			}
+			if tt.replayed && second.Body.String() != first.Body.String() {
+				t.Errorf("body = %q, want %q", second.Body.String(), first.Body.String())
+			}
+			if calls := handler.calls.Load(); calls != tt.calls {
+				t.Errorf("handler calls = %d, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyConcurrentWaiters(t *testing.T) {
+	handler := &countingHandler{release: make(chan struct{}), entered: make(chan struct{}, 1)}
+	idempotency := NewIdempotency(time.Hour, handler)
+	ctx := context.Background()
+
+	first := httptest.NewRecorder()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		idempotency.ServeHTTP(first, newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+	}()
+	<-handler.entered
+
+	// A waiter whose client goes away is told the request is still running.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	gone := httptest.NewRecorder()
+	idempotency.ServeHTTP(gone, newIdempotentRequest(canceled, "alice", http.MethodDelete, "k1", ""))
+	if gone.Code != http.StatusConflict || problemCode(t, gone) != "idempotent_request_in_progress" {
+		t.Errorf("canceled waiter got %d %q, want 409 idempotent_request_in_progress", gone.Code, gone.Body.String())
+	}
+
+	waiters := make([]*httptest.ResponseRecorder, 5)
+	for i := range waiters {
+		waiters[i] = httptest.NewRecorder()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			idempotency.ServeHTTP(waiters[i], newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+		}()
+	}
+	close(handler.release)
+	wg.Wait()
+
+	if calls := handler.calls.Load(); calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", calls)
+	}
+	for i, waiter := range waiters {
+		if waiter.Code != first.Code || waiter.Body.String() != first.Body.String() {
+			t.Errorf("waiter %d got %d %q, want %d %q", i, waiter.Code, waiter.Body.String(), first.Code, first.Body.String())
+		}
+		if waiter.Header().Get(IdempotencyReplayedHeader) != "true" {
+			t.Errorf("waiter %d was not replayed", i)
+		}
+	}
+}
+
+func TestIdempotencyServerErrorsAreForgotten(t *testing.T) {
+	handler := &countingHandler{statuses: []int{http.StatusInternalServerError, http.StatusOK}}
+	idempotency := NewIdempotency(time.Hour, handler)
+	ctx := context.Background()
+
+	wantStatuses := []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK}
+	wantCalls := []int32{1, 2, 2}
+	for i := range wantStatuses {
+		rec := httptest.NewRecorder()
+		idempotency.ServeHTTP(rec, newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+		if rec.Code != wantStatuses[i] {
+			t.Errorf("request %d: status = %d, want %d", i, rec.Code, wantStatuses[i])
+		}
+		if calls := handler.calls.Load(); calls != wantCalls[i] {
+			t.Errorf("request %d: handler calls = %d, want %d", i, calls, wantCalls[i])
+		}
+	}
+}
+
+func TestIdempotencyWaiterOfFailedRequest(t *testing.T) {
+	handler := &countingHandler{
+		statuses: []int{http.StatusInternalServerError},
+		release:  make(chan struct{}),
+		entered:  make(chan struct{}, 1),
+	}
+	idempotency := NewIdempotency(time.Hour, handler)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		idempotency.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+	}()
+	<-handler.entered
+
+	waiter := httptest.NewRecorder()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		idempotency.ServeHTTP(waiter, newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+	}()
+	// Let the waiter block on the running request; a waiter arriving after
+	// the failure runs the request again instead, which the handler refuses.
+	time.Sleep(50 * time.Millisecond)
+	close(handler.release)
+	wg.Wait()
+
+	if calls := handler.calls.Load(); calls != 1 {
+		t.Skipf("waiter arrived after the failure (%d calls)", calls)
+	}
+	if waiter.Code != http.StatusConflict || problemCode(t, waiter) != "idempotent_request_failed" {
+		t.Errorf("waiter got %d %q, want 409 idempotent_request_failed", waiter.Code, waiter.Body.String())
+	}
+}
+
+func TestIdempotencyExpiry(t *testing.T) {
+	handler := &countingHandler{}
+	idempotency := NewIdempotency(time.Millisecond, handler)
+	ctx := context.Background()
+
+	idempotency.ServeHTTP(httptest.NewRecorder(), newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+	time.Sleep(5 * time.Millisecond)
+	rec := httptest.NewRecorder()
+	idempotency.ServeHTTP(rec, newIdempotentRequest(ctx, "alice", http.MethodDelete, "k1", ""))
+
+	if rec.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Error("expired response was replayed")
+	}
+	if calls := handler.calls.Load(); calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type RequestRecorder interface {
+	RequestStarted()
+	RequestFinished(route, method string, status int, duration time.Duration)
+}
+
+// Metrics records request counts and latencies per route template, so that
+// path parameters such as pod names do not blow up label cardinality.
+type Metrics struct {
+	router   *mux.Router
+	recorder RequestRecorder
+	handler  http.Handler
+}
+
+// ServeHTTP handles the request by passing it to the real handler and recording its outcome
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route := "unmatched"
+	var match mux.RouteMatch
+	if m.router.Match(r, &match) && match.Route != nil {
+		if tmpl, err := match.Route.GetPathTemplate(); err == nil {
+			route = tmpl
+		}
+	}
+
+	start := time.Now()
+	m.recorder.RequestStarted()
+	sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		m.recorder.RequestFinished(route, r.Method, sw.status, time.Since(start))
+	}()
+	m.handler.ServeHTTP(sw, r)
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// NewMetrics constructs a new Metrics middleware handler resolving routes with router
+func NewMetrics(router *mux.Router, recorder RequestRecorder, handlerToWrap http.Handler) *Metrics {
+	return &Metrics{router: router, recorder: recorder, handler: handlerToWrap}
+}
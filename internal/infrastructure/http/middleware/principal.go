@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+)
+
+// Principal stores the caller identity in the request context. The identity
+// is read from a header set by the authenticating proxy in front of the executor.
+type Principal struct {
+	header  string
+	handler http.Handler
+}
+
+// ServeHTTP passes the request with the principal attached to the wrapped handler
+func (p *Principal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if principal := r.Header.Get(p.header); principal != "" {
+		r = r.WithContext(service.WithPrincipal(r.Context(), principal))
+	}
+	p.handler.ServeHTTP(w, r)
+}
+
+// NewPrincipal constructs a new Principal middleware handler
+func NewPrincipal(header string, handlerToWrap http.Handler) *Principal {
+	return &Principal{header, handlerToWrap}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/logging"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID propagates the X-Request-ID header of the caller, or generates
+// one, and attaches it to the request context and the response.
+type RequestID struct {
+	handler http.Handler
+}
+
+// ServeHTTP passes the request with its correlation ID to the wrapped handler
+func (m *RequestID) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestID := r.Header.Get(RequestIDHeader)
+	if requestID == "" || len(requestID) > 128 {
+		requestID = uuid.NewString()
+	}
+	w.Header().Set(RequestIDHeader, requestID)
+	m.handler.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+}
+
+// NewRequestID constructs a new RequestID middleware handler
+func NewRequestID(handlerToWrap http.Handler) *RequestID {
+	return &RequestID{handlerToWrap}
+}
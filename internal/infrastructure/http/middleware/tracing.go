@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// NewTracing constructs a middleware handler starting a server span per
+// request, named after the matched route template and continuing the W3C
+// trace context of the caller
+func NewTracing(router *mux.Router, handlerToWrap http.Handler) http.Handler {
+	return otelhttp.NewHandler(handlerToWrap, "http.server",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			var match mux.RouteMatch
+			if router.Match(r, &match) && match.Route != nil {
+				if tmpl, err := match.Route.GetPathTemplate(); err == nil {
+					return r.Method + " " + tmpl
+				}
+			}
+			return r.Method
+		}))
+}
@@ -0,0 +1,148 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/logging"
+	log "github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContentType is the media type of problem details.
+const ContentType = "application/problem+json"
+
+// StatusClientClosedRequest is replied, for the logs' sake, when the client
+// went away before the response was ready.
+const StatusClientClosedRequest = 499
+
+const codeInternal = "internal"
+
+// defaultRetryAfter is suggested to clients of a 429 reply when the cluster
+// gave no delay.
+const defaultRetryAfter = 5 * time.Second
+
+var kindStatus = map[service.Kind]int{
+	service.KindInternal:        http.StatusInternalServerError,
+	service.KindInvalid:         http.StatusBadRequest,
+	service.KindNotFound:        http.StatusNotFound,
+	service.KindForbidden:       http.StatusForbidden,
+	service.KindConflict:        http.StatusConflict,
+	service.KindGone:            http.StatusGone,
+	service.KindTimeout:         http.StatusGatewayTimeout,
+	service.KindTooManyRequests: http.StatusTooManyRequests,
+}
+
+type kubernetesProblem struct {
+	status int
+	code   string
+}
+
+// kubernetesReasons maps the reasons of Kubernetes API errors that were not
+// translated to domain errors.
+var kubernetesReasons = map[metav1.StatusReason]kubernetesProblem{
+	metav1.StatusReasonForbidden:          {http.StatusForbidden, "kubernetes_forbidden"},
+	metav1.StatusReasonUnauthorized:       {http.StatusBadGateway, "kubernetes_unauthorized"},
+	metav1.StatusReasonNotFound:           {http.StatusNotFound, "kubernetes_not_found"},
+	metav1.StatusReasonConflict:           {http.StatusConflict, "kubernetes_conflict"},
+	metav1.StatusReasonAlreadyExists:      {http.StatusConflict, "kubernetes_conflict"},
+	metav1.StatusReasonInvalid:            {http.StatusUnprocessableEntity, "kubernetes_invalid"},
+	metav1.StatusReasonBadRequest:         {http.StatusBadRequest, "kubernetes_bad_request"},
+	metav1.StatusReasonTimeout:            {http.StatusGatewayTimeout, "kubernetes_timeout"},
+	metav1.StatusReasonServerTimeout:      {http.StatusGatewayTimeout, "kubernetes_timeout"},
+	metav1.StatusReasonTooManyRequests:    {http.StatusTooManyRequests, "kubernetes_too_many_requests"},
+	metav1.StatusReasonServiceUnavailable: {http.StatusServiceUnavailable, "kubernetes_unavailable"},
+	metav1.StatusReasonExpired:            {http.StatusGone, "kubernetes_expired"},
+}
+
+// Write replies with problem details.
+func Write(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	write(w, r, &views.Problem{Status: status, Code: code, Detail: detail})
+}
+
+// WriteError replies with the problem details describing err and logs it.
+// The message of an unclassified error may leak internals, so fallback is
+// used as the detail instead.
+func WriteError(w http.ResponseWriter, r *http.Request, err error, fallback string) {
+	ctx := r.Context()
+	problem := classify(ctx, err, fallback)
+	entry := log.WithContext(ctx).WithField("code", problem.Code)
+	if problem.Status >= http.StatusInternalServerError {
+		entry.Error(err.Error())
+	} else {
+		entry.Info(err.Error())
+	}
+	if problem.Status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter(err).Seconds())))
+	}
+	problem.Pods = views.NewPodResults(podResults(err))
+	write(w, r, problem)
+}
+
+// retryAfter returns the delay after which a refused request may succeed.
+func retryAfter(err error) time.Duration {
+	var blocked *service.EvictionBlockedError
+	if errors.As(err, &blocked) && blocked.RetryAfter > 0 {
+		return blocked.RetryAfter
+	}
+	if seconds, ok := kerrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultRetryAfter
+}
+
+// podResults returns the outcome for each pod of an action that failed on
+// some of them.
+func podResults(err error) []*entity.PodResult {
+	var drain *service.DrainIncompleteError
+	if errors.As(err, &drain) {
+		return drain.Pods
+	}
+	var aborted *service.RollingRestartAbortedError
+	if errors.As(err, &aborted) {
+		return aborted.Pods
+	}
+	return nil
+}
+
+func classify(ctx context.Context, err error, fallback string) *views.Problem {
+	var domainErr *service.Error
+	if errors.As(err, &domainErr) {
+		return &views.Problem{Status: kindStatus[domainErr.Kind], Code: domainErr.Code, Detail: err.Error()}
+	}
+	if reason := kerrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		if p, ok := kubernetesReasons[reason]; ok {
+			return &views.Problem{Status: p.status, Code: p.code, Detail: err.Error(), Reason: string(reason)}
+		}
+	}
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		return &views.Problem{Status: StatusClientClosedRequest, Code: "canceled", Detail: "request canceled"}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &views.Problem{Status: http.StatusGatewayTimeout, Code: service.ErrTimeout.Code, Detail: fallback}
+	}
+	return &views.Problem{Status: http.StatusInternalServerError, Code: codeInternal, Detail: fallback}
+}
+
+func write(w http.ResponseWriter, r *http.Request, problem *views.Problem) {
+	problem.Type = "about:blank"
+	problem.Title = http.StatusText(problem.Status)
+	if problem.Status == StatusClientClosedRequest {
+		problem.Title = "Client Closed Request"
+	}
+	problem.Instance = r.URL.Path
+	problem.RequestID = logging.RequestIDFromContext(r.Context())
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+)
+
+// getApproval godoc
+//
+//	@Summary		Get Approval
+//	@Description	Get the state of an action waiting for approval
+//	@Tags			Approvals
+//	@Param			approval_id	path	string	true	"Approval ID"
+//	@Success		200			object	views.Approval
+//	@Failure		default		object	views.Problem
+//	@Router			/approvals/{approval_id} [get]
+func getApproval(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		approvalID := mux.Vars(r)["approval_id"]
+
+		approval, err := srv.GetApproval(ctx, approvalID)
+		if err != nil {
+			problem.WriteError(w, r, err, "failed to get approval")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewApproval(approval))
+	})
+}
+
+// approveAction godoc
+//
+//	@Summary		Approve Action
+//	@Description	Approve and execute an action waiting for approval. The approver must differ from the requester.
+//	@Tags			Approvals
+//	@Param			approval_id	path	string	true	"Approval ID"
+//	@Success		200			object	views.ActionResult
+//	@Failure		504			object	views.Problem	"The approved action did not complete in time"
+//	@Failure		default		object	views.Problem
+//	@Router			/approvals/{approval_id}/approve [post]
+func approveAction(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		approvalID := mux.Vars(r)["approval_id"]
+
+		result, err := srv.Approve(ctx, approvalID)
+		if err != nil {
+			problem.WriteError(w, r, err, "failed to execute approved action")
+			return
+		}
+		writeActionResult(w, result)
+	})
+}
+
+// rejectAction godoc
+//
+//	@Summary		Reject Action
+//	@Description	Discard an action waiting for approval. The rejecter must differ from the requester.
+//	@Tags			Approvals
+//	@Param			approval_id	path	string	true	"Approval ID"
+//	@Success		200			object	views.Approval
+//	@Failure		default		object	views.Problem
+//	@Router			/approvals/{approval_id}/reject [post]
+func rejectAction(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		approvalID := mux.Vars(r)["approval_id"]
+
+		approval, err := srv.Reject(ctx, approvalID)
+		if err != nil {
+			problem.WriteError(w, r, err, "failed to reject approval")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewApproval(approval))
+	})
+}
+
+func makeApprovalRoutes(r *mux.Router, srv *service.Executor) {
+	path := "/approvals"
+	serviceRouter := r.PathPrefix(path).Subrouter()
+	serviceRouter.Handle("/{approval_id}", getApproval(srv)).Methods("GET")
+	serviceRouter.Handle("/{approval_id}/approve", approveAction(srv)).Methods("POST")
+	serviceRouter.Handle("/{approval_id}/reject", rejectAction(srv)).Methods("POST")
+}
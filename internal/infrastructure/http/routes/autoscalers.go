@@ -0,0 +1,107 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+)
+
+// getAutoscaler godoc
+//
+//	@Summary		Get Horizontal Pod Autoscaler
+//	@Description	Get the limits, status and metrics of a horizontal pod autoscaler
+//	@Tags			Autoscalers
+//	@Param			namespace	path	string	true	"Name of namespace"
+//	@Param			hpa_name	path	string	true	"Name of horizontal pod autoscaler"
+//	@Success		200			object	views.HorizontalPodAutoscaler
+//	@Failure		default		object	views.Problem
+//	@Router			/kubernetes/{namespace}/horizontalpodautoscalers/{hpa_name} [get]
+func getAutoscaler(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to get horizontal pod autoscaler"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		name := mux.Vars(r)["hpa_name"]
+
+		hpa, err := srv.GetHorizontalPodAutoscaler(ctx, namespace, name)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewHorizontalPodAutoscaler(hpa))
+	})
+}
+
+// setAutoscalerLimits godoc
+//
+//	@Summary		Set Horizontal Pod Autoscaler Limits
+//	@Description	Change the replica range of a horizontal pod autoscaler, which is how to scale a workload it owns
+//	@Tags			Autoscalers
+//	@Param			namespace	path	string	true	"Name of namespace"
+//	@Param			hpa_name	path	string	true	"Name of horizontal pod autoscaler"
+//	@Param			minReplicas	query	int		false	"Minimum number of replicas; unchanged when absent"
+//	@Param			maxReplicas	query	int		false	"Maximum number of replicas; unchanged when absent"
+//	@Param			dryRun		query	bool	false	"Validate and compute changes without applying them"
+//	@Success		200			object	views.ActionResult
+//	@Success		202			object	views.ActionResult	"Waiting for approval"
+//	@Failure		403			object	views.Problem	"Refused by policy"
+//	@Failure		409			object	views.Problem	"Another action is running on the autoscaler"
+//	@Failure		default		object	views.Problem
+//	@Router			/kubernetes/{namespace}/horizontalpodautoscalers/{hpa_name} [put]
+func setAutoscalerLimits(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to set horizontal pod autoscaler limits"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		name := mux.Vars(r)["hpa_name"]
+		minReplicas, err := queryReplicas(r, "minReplicas")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("minReplicas", err), errMsg)
+			return
+		}
+		maxReplicas, err := queryReplicas(r, "maxReplicas")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("maxReplicas", err), errMsg)
+			return
+		}
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+
+		result, err := srv.SetAutoscalerLimits(ctx, namespace, name, minReplicas, maxReplicas, service.ActionOptions{DryRun: dryRun})
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		writeActionResult(w, result)
+	})
+}
+
+// queryReplicas parses an optional replica count; nil when absent.
+func queryReplicas(r *http.Request, name string) (*int32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	res := int32(replicas)
+	return &res, nil
+}
+
+func makeAutoscalerRoutes(r *mux.Router, srv *service.Executor) {
+	r.Handle("/{namespace}/horizontalpodautoscalers/{hpa_name}", getAutoscaler(srv)).Methods("GET")
+	r.Handle("/{namespace}/horizontalpodautoscalers/{hpa_name}", setAutoscalerLimits(srv)).Methods("PUT")
+}
@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/application"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// clusterCheckTimeout bounds the connectivity check of a single cluster.
+const clusterCheckTimeout = 5 * time.Second
+
+// listClusters godoc
+//
+//	@Summary		List Clusters
+//	@Description	List the clusters served by the executor and whether their API server is reachable.
+//	@Description	Routes under /clusters/{cluster}/ act on the named cluster, the other routes on the default one.
+//	@Tags			Clusters
+//	@Success		200	{array}	views.Cluster
+//	@Router			/clusters [get]
+func listClusters(app *application.Application) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		clusters := make([]*views.Cluster, 0, len(app.Clusters))
+		var wg sync.WaitGroup
+		for _, cluster := range app.Clusters {
+			view := &views.Cluster{
+				Name:      cluster.Name,
+				Default:   cluster.Name == app.DefaultCluster,
+				Host:      cluster.Host,
+				Namespace: cluster.Namespace,
+			}
+			clusters = append(clusters, view)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				checkCtx, cancel := context.WithTimeout(ctx, clusterCheckTimeout)
+				defer cancel()
+				version, err := cluster.ExecutorService.ServerVersion(checkCtx)
+				if err != nil {
+					log.WithContext(ctx).Infof("cluster %s is unreachable: %v", cluster.Name, err)
+					view.Error = err.Error()
+					return
+				}
+				view.Connected = true
+				view.ServerVersion = version
+			}()
+		}
+		wg.Wait()
+		sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(clusters)
+	})
+}
+
+func makeClusterRoutes(r *mux.Router, app *application.Application) {
+	path := "/clusters"
+	serviceRouter := r.PathPrefix(path).Subrouter()
+	serviceRouter.Handle("", listClusters(app)).Methods("GET")
+	for name, cluster := range app.Clusters {
+		clusterRouter := serviceRouter.PathPrefix("/" + name).Subrouter()
+		makeKubernetesRoutes(clusterRouter, cluster.ExecutorService)
+		makeNodeRoutes(clusterRouter, cluster.ExecutorService)
+		makeApprovalRoutes(clusterRouter, cluster.ExecutorService)
+	}
+}
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+)
+
+// listPodDisruptionBudgets godoc
+//
+//	@Summary		List Pod Disruption Budgets of Pod
+//	@Description	List the PodDisruptionBudgets covering a pod
+//	@Tags			Pods
+//	@Param			namespace	path	string	true	"Name of namespace"
+//	@Param			pod_name	path	string	true	"Name of pod"
+//	@Success		200			object	views.DisruptionBudgetList
+//	@Failure		default		object	views.Problem
+//	@Router			/kubernetes/{namespace}/pods/{pod_name}/disruptionbudgets [get]
+func listPodDisruptionBudgets(srv *service.Executor) http.Handler {
+	return listDisruptionBudgets("failed to list pod disruption budgets of pod", "pod_name", srv.ListPodDisruptionBudgets)
+}
+
+// listDeploymentDisruptionBudgets godoc
+//
+//	@Summary		List Pod Disruption Budgets of Deployment
+//	@Description	List the PodDisruptionBudgets covering the pods of a deployment
+//	@Tags			Deployments
+//	@Param			namespace		path	string	true	"Name of namespace"
+//	@Param			deployment_name	path	string	true	"Name of Deployment"
+//	@Success		200				object	views.DisruptionBudgetList
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/{namespace}/deployments/{deployment_name}/disruptionbudgets [get]
+func listDeploymentDisruptionBudgets(srv *service.Executor) http.Handler {
+	return listDisruptionBudgets("failed to list pod disruption budgets of deployment", "deployment_name", srv.ListDeploymentDisruptionBudgets)
+}
+
+func listDisruptionBudgets(errMsg, nameVar string,
+	list func(ctx context.Context, namespace, name string) ([]*entity.DisruptionBudget, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		name := mux.Vars(r)[nameVar]
+
+		budgets, err := list(ctx, namespace, name)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewDisruptionBudgetList(budgets))
+	})
+}
@@ -0,0 +1,87 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/application"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/buildinfo"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// healthCheckTimeout bounds each call to the Kubernetes API made by a probe.
+const healthCheckTimeout = 5 * time.Second
+
+// healthPaths are polled by probes and kept out of the access log.
+var healthPaths = []string{"/healthz", "/readyz", "/version"}
+
+// healthz reports that the process is alive.
+func healthz() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+}
+
+// readyz reports whether the Kubernetes API of the default cluster is
+// reachable. The metrics API only feeds container usage, so it is reported
+// without affecting readiness.
+func readyz(app *application.Application) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+
+		readiness := &views.Readiness{Status: "ok", Checks: map[string]*views.Check{}}
+		status := http.StatusOK
+		if _, err := app.ExecutorService.ServerVersion(ctx); err != nil {
+			log.WithContext(ctx).Warnf("not ready: %v", err)
+			readiness.Status = "unavailable"
+			readiness.Checks["kubernetes"] = &views.Check{Error: err.Error()}
+			status = http.StatusServiceUnavailable
+		} else {
+			readiness.Checks["kubernetes"] = &views.Check{OK: true}
+		}
+		if err := app.ExecutorService.CheckMetricsAPI(ctx); err != nil {
+			readiness.Checks["metrics"] = &views.Check{Error: err.Error()}
+		} else {
+			readiness.Checks["metrics"] = &views.Check{OK: true}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(readiness)
+	})
+}
+
+// version reports the build of the executor and the version of the default
+// cluster.
+func version(app *application.Application) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+
+		info := buildinfo.Get()
+		view := &views.Version{Commit: info.Commit, GoVersion: info.GoVersion}
+		serverVersion, err := app.ExecutorService.ServerVersion(ctx)
+		if err != nil {
+			view.Error = err.Error()
+		}
+		view.ServerVersion = serverVersion
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(view)
+	})
+}
+
+func makeHealthRoutes(r *mux.Router, app *application.Application) {
+	r.Handle("/healthz", healthz()).Methods("GET")
+	r.Handle("/readyz", readyz(app)).Methods("GET")
+	r.Handle("/version", version(app)).Methods("GET")
+}
@@ -3,25 +3,37 @@ package routes
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/inviewteam/fenrir.executor/internal/application"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
 	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
 	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
-
-	log "github.com/sirupsen/logrus"
 )
 
 // restartPod godoc
 //
 //	@Summary		Restart Pod
-//	@Description	restart pod
+//	@Description	Remove a pod and wait until its controller replaced it with a ready pod, reported as the replacement
 //	@Tags			Pods
 //	@Param			namespace	path	string	true	"Name of namespace"
 //	@Param			pod_name	path	string	true	"Name of pod"
-//	@Success		200
+//	@Param			dryRun		query	bool	false	"Validate and compute changes without applying them"
+//	@Param			timeout		query	string	false	"Maximum time to wait for the pod to be replaced by a ready pod, e.g. 90s; capped by the configured wait timeout"
+//	@Param			mode		query	string	false	"evict honours PodDisruptionBudgets, delete bypasses them; the configured restart mode when absent"	Enums(evict, delete)
+//	@Param			gracePeriod	query	string	false	"Termination grace period of the pod, e.g. 30s; its own when absent"
+//	@Param			force		query	bool	false	"Restart even though a PodDisruptionBudget allows no disruption, with a warning; evictions are still refused by the API server, unlike deletions"
+//	@Success		200			object	views.ActionResult
+//	@Success		202			object	views.ActionResult	"Waiting for approval"
+//	@Failure		403			object	views.Problem	"Refused by policy"
+//	@Failure		409			object	views.Problem	"Another action is running on the pod, a PodDisruptionBudget allows no disruption, or the replacement pod failed"
+//	@Failure		429			object	views.Problem	"Eviction blocked by a PodDisruptionBudget; retry after the Retry-After header"
+//	@Failure		504			object	views.Problem	"The pod was not replaced in time"
+//	@Failure		default		object	views.Problem
 //	@Router			/kubernetes/{namespace}/pods/{pod_name} [delete]
 func restartPod(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -29,19 +41,39 @@ func restartPod(srv *service.Executor) http.Handler {
 		ctx := r.Context()
 		namespace := mux.Vars(r)["namespace"]
 		podName := mux.Vars(r)["pod_name"]
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+		timeout, err := queryDuration(r, "timeout")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("timeout", err), errMsg)
+			return
+		}
+		gracePeriod, err := queryGracePeriod(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		force, err := queryBool(r, "force")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("force", err), errMsg)
+			return
+		}
 
-		err := srv.Restart(ctx, namespace, podName)
+		result, err := srv.Restart(ctx, namespace, podName, service.ActionOptions{
+			DryRun:      dryRun,
+			Timeout:     timeout,
+			RestartMode: service.RestartMode(r.URL.Query().Get("mode")),
+			GracePeriod: gracePeriod,
+			Force:       force,
+		})
 		if err != nil {
-			if errors.Is(err, service.ErrPodNotFound) {
-				log.Info("pod not found")
-				http.Error(w, "pod not found", http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		writeActionResult(w, result)
 	})
 }
 
@@ -53,7 +85,15 @@ func restartPod(srv *service.Executor) http.Handler {
 //	@Param			namespace		path	string	true	"Name of namespace"
 //	@Param			deployment_name	path	string	true	"Name of Deployment"
 //	@Param			replicas		query	string	true	"Amount of Replicas"
-//	@Success		200
+//	@Param			dryRun			query	bool	false	"Validate and compute changes without applying them"
+//	@Param			timeout			query	string	false	"Maximum time to wait for the replicas to be ready, e.g. 90s; capped by the configured wait timeout"
+//	@Param			force			query	bool	false	"Scale even though a horizontal pod autoscaler owns the replicas or a PodDisruptionBudget requires more healthy pods, with a warning"
+//	@Success		200				object	views.ActionResult
+//	@Success		202				object	views.ActionResult	"Waiting for approval"
+//	@Failure		403				object	views.Problem	"Refused by policy"
+//	@Failure		409				object	views.Problem	"Another action is running on the deployment, a horizontal pod autoscaler owns its replicas, or a PodDisruptionBudget requires more healthy pods"
+//	@Failure		504				object	views.Problem	"The deployment was not scaled in time"
+//	@Failure		default			object	views.Problem
 //	@Router			/kubernetes/{namespace}/deployments/{deployment_name} [put]
 func scaleDeployment(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -63,23 +103,32 @@ func scaleDeployment(srv *service.Executor) http.Handler {
 		deploymentName := mux.Vars(r)["deployment_name"]
 		replicas := r.URL.Query().Get("replicas")
 
-		targetReplicas, err := strconv.Atoi(replicas)
+		targetReplicas, err := strconv.ParseInt(replicas, 10, 32)
 		if err != nil {
-			log.Info("wrong payload")
-			http.Error(w, "bad request", http.StatusBadRequest)
+			problem.WriteError(w, r, invalidArgument("replicas", err), errMsg)
 			return
 		}
-		err = srv.Scale(ctx, namespace, deploymentName, int32(targetReplicas))
+		dryRun, err := queryBool(r, "dryRun")
 		if err != nil {
-			if errors.Is(err, service.ErrDeploymentNotFound) {
-				log.Info("deployment not found")
-				http.Error(w, "deployment not found", http.StatusNotFound)
-			}
-			log.Error(err.Error())
-			http.Error(w, errMsg, http.StatusInternalServerError)
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		timeout, err := queryDuration(r, "timeout")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("timeout", err), errMsg)
+			return
+		}
+		force, err := queryBool(r, "force")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("force", err), errMsg)
+			return
+		}
+		result, err := srv.Scale(ctx, namespace, deploymentName, int32(targetReplicas), service.ActionOptions{DryRun: dryRun, Timeout: timeout, Force: force})
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		writeActionResult(w, result)
 	})
 }
 
@@ -91,62 +140,49 @@ func scaleDeployment(srv *service.Executor) http.Handler {
 //	@Param			namespace	path	string	true	"Name of namespace"
 //	@Param			pod_name	path	string	true	"Name of pod"
 //	@Success		200			object	views.Pod
+//	@Failure		default		object	views.Problem
 //	@Router			/kubernetes/{namespace}/pods/{pod_name} [get]
 func getPodInformation(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		errMsg := "failed to restart pod"
+		errMsg := "failed to get pod"
 		ctx := r.Context()
 		namespace := mux.Vars(r)["namespace"]
 		podName := mux.Vars(r)["pod_name"]
 
 		pod, err := srv.GetPodByName(ctx, namespace, podName)
 		if err != nil {
-			if errors.Is(err, service.ErrPodNotFound) {
-				log.Info("pod not found")
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(views.NewPod(pod))
 	})
 }
 
-// listPodsByDeployment godoc
-//
-//	@Summary		Lists Pods by Deployment
-//	@Description	Lists Pods by Deployment
-//	@Tags			Pods
-//	@Param			namespace	path	string	true	"Name of namespace"
-//	@Param			deployment	query	string	true	"Name of deployment"
-//	@Success		200			object	views.DeploymentPods
-//	@Router			/kubernetes/{namespace}/pods [get]
+// listPodByDeployment lists the pods of the deployment query parameter. It
+// predates listPods, which documents the route, and keeps its reply shape.
 func listPodByDeployment(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		errMsg := "failed to restart pod"
+		errMsg := "failed to list pods"
 		ctx := r.Context()
 		namespace := mux.Vars(r)["namespace"]
 		deployment := r.URL.Query().Get("deployment")
+		withMetrics, err := queryBool(r, "metrics")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("metrics", err), errMsg)
+			return
+		}
 
-		pods, err := srv.ListPodByDeployment(ctx, namespace, deployment)
+		pods, err := srv.ListPodByDeployment(ctx, namespace, deployment, withMetrics)
 		if err != nil {
-			if errors.Is(err, service.ErrDeploymentNotFound) {
-				log.Info("deployment not found")
-				http.Error(w, service.ErrDeploymentNotFound.Error(), http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(views.NewPods(pods))
 	})
 }
@@ -159,9 +195,11 @@ func listPodByDeployment(srv *service.Executor) http.Handler {
 //	@Param			namespace	 path	string	true	"Namespace name"
 //	@Param			deployment_name path	string	true	"Deployment name"
 //	@Success		200			object	views.Deployment
+//	@Failure		default		object	views.Problem
 //	@Router			/kubernetes/{namespace}/deployments/{deployment_name} [get]
 func getDeploymentInformation(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to get deployment info"
 		ctx := r.Context()
 		vars := mux.Vars(r)
 		namespace := vars["namespace"]
@@ -169,19 +207,13 @@ func getDeploymentInformation(srv *service.Executor) http.Handler {
 
 		deployment, err := srv.GetDeploymentByName(ctx, namespace, deploymentName)
 		if err != nil {
-			if errors.Is(err, service.ErrDeploymentNotFound) {
-				log.Info("deployment not found")
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, "failed to get deployment info", http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(deployment)
+		json.NewEncoder(w).Encode(views.NewDeployment(deployment))
 	})
 }
 
@@ -195,6 +227,7 @@ func getDeploymentInformation(srv *service.Executor) http.Handler {
 //	@Param			container	query	string	true	"Name of container"
 //	@Param			tail		query	int		false	"Number of lines to show"
 //	@Success		200			string	string
+//	@Failure		default		object	views.Problem
 //	@Router			/kubernetes/{namespace}/pods/{pod_name}/logs [get]
 func getPodLogs(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -210,21 +243,14 @@ func getPodLogs(srv *service.Executor) http.Handler {
 			var err error
 			tailLines, err = strconv.ParseInt(tailLinesStr, 10, 64)
 			if err != nil {
-				log.Info("wrong payload")
-				http.Error(w, "bad request", http.StatusBadRequest)
+				problem.WriteError(w, r, invalidArgument("tail", err), errMsg)
 				return
 			}
 		}
 
 		logs, err := srv.GetPodLogs(ctx, namespace, podName, containerName, tailLines)
 		if err != nil {
-			if errors.Is(err, service.ErrPodNotFound) {
-				log.Info("pod not found")
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
 
@@ -241,6 +267,7 @@ func getPodLogs(srv *service.Executor) http.Handler {
 //	@Param			namespace	path	string	true	"Name of namespace"
 //	@Param			pod_name	path	string	true	"Name of pod"
 //	@Success		200			string	string
+//	@Failure		default		object	views.Problem
 //	@Router			/kubernetes/{namespace}/pods/{pod_name}/describe [get]
 func describePod(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -251,13 +278,7 @@ func describePod(srv *service.Executor) http.Handler {
 
 		desc, err := srv.DescribePod(ctx, namespace, podName)
 		if err != nil {
-			if errors.Is(err, service.ErrPodNotFound) {
-				log.Info("pod not found")
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
 
@@ -274,6 +295,7 @@ func describePod(srv *service.Executor) http.Handler {
 //	@Param			namespace		path	string	true	"Name of namespace"
 //	@Param			deployment_name	path	string	true	"Name of Deployment"
 //	@Success		200				string	string
+//	@Failure		default			object	views.Problem
 //	@Router			/kubernetes/{namespace}/deployments/{deployment_name}/describe [get]
 func describeDeployment(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -284,13 +306,7 @@ func describeDeployment(srv *service.Executor) http.Handler {
 
 		desc, err := srv.DescribeDeployment(ctx, namespace, deploymentName)
 		if err != nil {
-			if errors.Is(err, service.ErrDeploymentNotFound) {
-				log.Info("deployment not found")
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
 
@@ -306,7 +322,12 @@ func describeDeployment(srv *service.Executor) http.Handler {
 //	@Tags			Deployments
 //	@Param			namespace		path	string	true	"Namespace name"
 //	@Param			deployment_name	path	string	true	"Deployment name"
-//	@Success		200
+//	@Param			dryRun			query	bool	false	"Validate and compute changes without applying them"
+//	@Success		200				object	views.ActionResult
+//	@Success		202				object	views.ActionResult	"Waiting for approval"
+//	@Failure		403				object	views.Problem	"Refused by policy"
+//	@Failure		409				object	views.Problem	"Another action is running on the deployment"
+//	@Failure		default			object	views.Problem
 //	@Router			/kubernetes/{namespace}/deployments/{deployment_name}/rollback [post]
 func rollbackDeployment(srv *service.Executor) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -314,35 +335,185 @@ func rollbackDeployment(srv *service.Executor) http.Handler {
 		ctx := r.Context()
 		namespace := mux.Vars(r)["namespace"]
 		deploymentName := mux.Vars(r)["deployment_name"]
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+
+		result, err := srv.Rollback(ctx, namespace, deploymentName, service.ActionOptions{DryRun: dryRun})
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		writeActionResult(w, result)
+	})
+}
+
+// restartDeployment godoc
+//
+//	@Summary		Rolling Restart Deployment
+//	@Description	Restart the pods of a deployment in waves, waiting for each wave to be replaced by ready pods, and report the outcome per pod
+//	@Tags			Deployments
+//	@Param			namespace		path	string	true	"Namespace name"
+//	@Param			deployment_name	path	string	true	"Deployment name"
+//	@Param			waveSize		query	int		false	"Number of pods restarted at once"	default(1)
+//	@Param			maxFailures		query	int		false	"Number of pods allowed to fail before the remaining waves are cancelled"	default(0)
+//	@Param			dryRun			query	bool	false	"Validate and compute changes without applying them"
+//	@Param			timeout			query	string	false	"Maximum time to wait for each pod to be replaced by a ready pod, e.g. 90s; capped by the configured wait timeout"
+//	@Param			mode			query	string	false	"evict honours PodDisruptionBudgets, delete bypasses them; the configured restart mode when absent"	Enums(evict, delete)
+//	@Param			gracePeriod		query	string	false	"Termination grace period of the pods, e.g. 30s; their own when absent"
+//	@Param			force			query	bool	false	"Restart pods even though a PodDisruptionBudget allows no disruption, with a warning; evictions are still refused by the API server, unlike deletions"
+//	@Success		200				object	views.ActionResult
+//	@Success		202				object	views.ActionResult	"Waiting for approval"
+//	@Failure		403				object	views.Problem	"Refused by policy"
+//	@Failure		409				object	views.Problem	"Another action is running on the deployment, or too many pods failed, e.g. because a PodDisruptionBudget allows no disruption; pods reports the outcome per pod"
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/{namespace}/deployments/{deployment_name}/restart [put]
+func restartDeployment(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to restart deployment"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		deploymentName := mux.Vars(r)["deployment_name"]
+		waveSize, err := queryInt(r, "waveSize", 1)
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("waveSize", err), errMsg)
+			return
+		}
+		maxFailures, err := queryInt(r, "maxFailures", 0)
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("maxFailures", err), errMsg)
+			return
+		}
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+		timeout, err := queryDuration(r, "timeout")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("timeout", err), errMsg)
+			return
+		}
+		gracePeriod, err := queryGracePeriod(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		force, err := queryBool(r, "force")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("force", err), errMsg)
+			return
+		}
 
-		err := srv.Rollback(ctx, namespace, deploymentName)
+		result, err := srv.RollingRestart(ctx, namespace, deploymentName, service.RollingRestartOptions{
+			ActionOptions: service.ActionOptions{
+				DryRun:      dryRun,
+				Timeout:     timeout,
+				RestartMode: service.RestartMode(r.URL.Query().Get("mode")),
+				GracePeriod: gracePeriod,
+				Force:       force,
+			},
+			WaveSize:    waveSize,
+			MaxFailures: maxFailures,
+		})
 		if err != nil {
-			if errors.Is(err, service.ErrDeploymentNotFound) {
-				log.Info("deployment not found")
-				http.Error(w, "deployment not found", http.StatusNotFound)
-			} else if errors.Is(err, service.ErrNoPreviousRevisionsFound) {
-				log.Info("no available revisions")
-				http.Error(w, "deployment not found", http.StatusBadRequest)
-			} else {
-				log.Error(err.Error())
-				http.Error(w, errMsg, http.StatusInternalServerError)
-			}
+			problem.WriteError(w, r, err, errMsg)
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		writeActionResult(w, result)
 	})
 }
 
-func makeKubernetesRoutes(r *mux.Router, app *application.Application) {
+// writeActionResult replies 200 with the result of an executed action, or 202
+// when the action is waiting for approval.
+func writeActionResult(w http.ResponseWriter, result *entity.ActionResult) {
+	status := http.StatusOK
+	if result.Approval != nil {
+		status = http.StatusAccepted
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(views.NewActionResult(result))
+}
+
+// queryBool parses an optional boolean query parameter, defaulting to false.
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// queryInt parses an optional integer query parameter, defaulting to def.
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// invalidArgument reports a query parameter that failed to parse.
+func invalidArgument(name string, err error) error {
+	return &service.InvalidArgumentError{Argument: name, Reason: err.Error()}
+}
+
+// queryDuration parses an optional duration such as "90s"; zero when absent.
+func queryDuration(r *http.Request, name string) (time.Duration, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s must be positive", name)
+	}
+	return d, nil
+}
+
+// queryGracePeriod parses an optional grace period such as "30s"; nil when
+// absent. Zero is allowed and deletes the pods immediately.
+func queryGracePeriod(r *http.Request) (*time.Duration, error) {
+	value := r.URL.Query().Get("gracePeriod")
+	if value == "" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, invalidArgument("gracePeriod", err)
+	}
+	if d < 0 {
+		return nil, invalidArgument("gracePeriod", errors.New("gracePeriod must not be negative"))
+	}
+	return &d, nil
+}
+
+func makeKubernetesRoutes(r *mux.Router, srv *service.Executor) {
 	path := "/kubernetes"
 	serviceRouter := r.PathPrefix(path).Subrouter()
-	serviceRouter.Handle("/{namespace}/pods/{pod_name}", getPodInformation(app.ExecutorService)).Methods("GET")
-	serviceRouter.Handle("/{namespace}/pods/{pod_name}", restartPod(app.ExecutorService)).Methods("DELETE")
-	serviceRouter.Handle("/{namespace}/pods", listPodByDeployment(app.ExecutorService)).Methods("GET")
-	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}", getDeploymentInformation(app.ExecutorService)).Methods("GET")
-	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}", scaleDeployment(app.ExecutorService)).Methods("PUT")
-	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}/rollback", rollbackDeployment(app.ExecutorService)).Methods("PUT")
-	serviceRouter.Handle("/{namespace}/pods/{pod_name}/logs", getPodLogs(app.ExecutorService)).Methods("GET")
-	serviceRouter.Handle("/{namespace}/pods/{pod_name}/describe", describePod(app.ExecutorService)).Methods("GET")
-	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}/describe", describeDeployment(app.ExecutorService)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/pods/{pod_name}", getPodInformation(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/pods/{pod_name}", restartPod(srv)).Methods("DELETE")
+	serviceRouter.Handle("/namespaces", listNamespaces(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/pods", listPodByDeployment(srv)).Methods("GET").Queries("deployment", "{deployment}")
+	serviceRouter.Handle("/{namespace}/pods", listPods(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/deployments", listDeployments(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/statefulsets", listStatefulSets(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/services", listServices(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}", getDeploymentInformation(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}", scaleDeployment(srv)).Methods("PUT")
+	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}/rollback", rollbackDeployment(srv)).Methods("PUT")
+	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}/restart", restartDeployment(srv)).Methods("PUT")
+	serviceRouter.Handle("/{namespace}/pods/{pod_name}/logs", getPodLogs(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/pods/{pod_name}/describe", describePod(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}/describe", describeDeployment(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/pods/{pod_name}/disruptionbudgets", listPodDisruptionBudgets(srv)).Methods("GET")
+	serviceRouter.Handle("/{namespace}/deployments/{deployment_name}/disruptionbudgets", listDeploymentDisruptionBudgets(srv)).Methods("GET")
+	makeAutoscalerRoutes(serviceRouter, srv)
+	makeScaleRoutes(serviceRouter, srv)
 }
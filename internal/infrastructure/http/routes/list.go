@@ -0,0 +1,265 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+)
+
+// queryListOptions reads the selectors, pagination and order of a list.
+func queryListOptions(r *http.Request) (entity.ListOptions, error) {
+	query := r.URL.Query()
+	opts := entity.ListOptions{
+		LabelSelector: query.Get("labelSelector"),
+		FieldSelector: query.Get("fieldSelector"),
+		Continue:      query.Get("continue"),
+		SortBy:        query.Get("sortBy"),
+	}
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		if opts.Limit, err = strconv.ParseInt(limit, 10, 64); err != nil {
+			return opts, invalidArgument("limit", err)
+		}
+	}
+	return opts, nil
+}
+
+// listNamespaces godoc
+//
+//	@Summary		List Namespaces
+//	@Description	List namespaces, one page at a time
+//	@Tags			Namespaces
+//	@Param			labelSelector	query	string	false	"Kubernetes label selector, e.g. app=payments"
+//	@Param			fieldSelector	query	string	false	"Kubernetes field selector, e.g. status.phase=Active"
+//	@Param			limit			query	int		false	"Maximum number of items in the page"
+//	@Param			continue		query	string	false	"Continue token of the previous page"
+//	@Param			sortBy			query	string	false	"Order of the page: name or age, prefixed with - for descending"
+//	@Success		200				object	views.NamespaceList
+//	@Failure		400				object	views.Problem	"Bad selector or query parameter"
+//	@Failure		410				object	views.Problem	"The continue token expired"
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/namespaces [get]
+func listNamespaces(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to list namespaces"
+		ctx := r.Context()
+		opts, err := queryListOptions(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		list, err := srv.ListNamespaces(ctx, opts)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewNamespaceList(list))
+	})
+}
+
+// listNodes godoc
+//
+//	@Summary		List Nodes
+//	@Description	List nodes, one page at a time
+//	@Tags			Nodes
+//	@Param			labelSelector	query	string	false	"Kubernetes label selector, e.g. app=payments"
+//	@Param			fieldSelector	query	string	false	"Kubernetes field selector, e.g. spec.unschedulable=true"
+//	@Param			limit			query	int		false	"Maximum number of items in the page"
+//	@Param			continue		query	string	false	"Continue token of the previous page"
+//	@Param			sortBy			query	string	false	"Order of the page: name or age, prefixed with - for descending"
+//	@Success		200				object	views.NodeList
+//	@Failure		400				object	views.Problem	"Bad selector or query parameter"
+//	@Failure		410				object	views.Problem	"The continue token expired"
+//	@Failure		default			object	views.Problem
+//	@Router			/nodes [get]
+func listNodes(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to list nodes"
+		ctx := r.Context()
+		opts, err := queryListOptions(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		list, err := srv.ListNodes(ctx, opts)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewNodeList(list))
+	})
+}
+
+// listPods godoc
+//
+//	@Summary		List Pods
+//	@Description	List pods of the namespace, one page at a time
+//	@Tags			Pods
+//	@Param			namespace		path	string	true	"Name of namespace"
+//	@Param			labelSelector	query	string	false	"Kubernetes label selector, e.g. app=payments"
+//	@Param			fieldSelector	query	string	false	"Kubernetes field selector, e.g. status.phase=Running"
+//	@Param			limit			query	int		false	"Maximum number of items in the page"
+//	@Param			continue		query	string	false	"Continue token of the previous page"
+//	@Param			sortBy			query	string	false	"Order of the page: name or age, prefixed with - for descending"
+//	@Param			deployment		query	string	false	"List the pods of the deployment as views.DeploymentPods instead, with their ReplicaSet revision; other list parameters are ignored"
+//	@Param			metrics			query	bool	false	"With deployment, include container usage fetched in one metrics call"
+//	@Description	Served by the informer cache when enabled for the namespace and neither fieldSelector nor pagination is used
+//	@Success		200				object	views.PodList
+//	@Failure		400				object	views.Problem	"Bad selector or query parameter"
+//	@Failure		410				object	views.Problem	"The continue token expired"
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/{namespace}/pods [get]
+func listPods(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to list pods"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		opts, err := queryListOptions(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		list, err := srv.ListPods(ctx, namespace, opts)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewPodList(list))
+	})
+}
+
+// listDeployments godoc
+//
+//	@Summary		List Deployments
+//	@Description	List deployments of the namespace, one page at a time
+//	@Tags			Deployments
+//	@Param			namespace		path	string	true	"Name of namespace"
+//	@Param			labelSelector	query	string	false	"Kubernetes label selector, e.g. app=payments"
+//	@Param			fieldSelector	query	string	false	"Kubernetes field selector, e.g. metadata.name=api"
+//	@Param			limit			query	int		false	"Maximum number of items in the page"
+//	@Param			continue		query	string	false	"Continue token of the previous page"
+//	@Param			sortBy			query	string	false	"Order of the page: name or age, prefixed with - for descending"
+//	@Description	Served by the informer cache when enabled for the namespace and neither fieldSelector nor pagination is used
+//	@Success		200				object	views.DeploymentList
+//	@Failure		400				object	views.Problem	"Bad selector or query parameter"
+//	@Failure		410				object	views.Problem	"The continue token expired"
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/{namespace}/deployments [get]
+func listDeployments(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to list deployments"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		opts, err := queryListOptions(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		list, err := srv.ListDeployments(ctx, namespace, opts)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewDeploymentList(list))
+	})
+}
+
+// listStatefulSets godoc
+//
+//	@Summary		List StatefulSets
+//	@Description	List statefulsets of the namespace, one page at a time
+//	@Tags			StatefulSets
+//	@Param			namespace		path	string	true	"Name of namespace"
+//	@Param			labelSelector	query	string	false	"Kubernetes label selector, e.g. app=payments"
+//	@Param			fieldSelector	query	string	false	"Kubernetes field selector, e.g. metadata.name=db"
+//	@Param			limit			query	int		false	"Maximum number of items in the page"
+//	@Param			continue		query	string	false	"Continue token of the previous page"
+//	@Param			sortBy			query	string	false	"Order of the page: name or age, prefixed with - for descending"
+//	@Success		200				object	views.StatefulSetList
+//	@Failure		400				object	views.Problem	"Bad selector or query parameter"
+//	@Failure		410				object	views.Problem	"The continue token expired"
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/{namespace}/statefulsets [get]
+func listStatefulSets(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to list statefulsets"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		opts, err := queryListOptions(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		list, err := srv.ListStatefulSets(ctx, namespace, opts)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewStatefulSetList(list))
+	})
+}
+
+// listServices godoc
+//
+//	@Summary		List Services
+//	@Description	List services of the namespace, one page at a time
+//	@Tags			Services
+//	@Param			namespace		path	string	true	"Name of namespace"
+//	@Param			labelSelector	query	string	false	"Kubernetes label selector, e.g. app=payments"
+//	@Param			fieldSelector	query	string	false	"Kubernetes field selector, e.g. spec.type=LoadBalancer"
+//	@Param			limit			query	int		false	"Maximum number of items in the page"
+//	@Param			continue		query	string	false	"Continue token of the previous page"
+//	@Param			sortBy			query	string	false	"Order of the page: name or age, prefixed with - for descending"
+//	@Success		200				object	views.ServiceList
+//	@Failure		400				object	views.Problem	"Bad selector or query parameter"
+//	@Failure		410				object	views.Problem	"The continue token expired"
+//	@Failure		default			object	views.Problem
+//	@Router			/kubernetes/{namespace}/services [get]
+func listServices(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to list services"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+		opts, err := queryListOptions(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		list, err := srv.ListServices(ctx, namespace, opts)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewServiceList(list))
+	})
+}
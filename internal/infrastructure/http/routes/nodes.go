@@ -0,0 +1,162 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+)
+
+// getNode godoc
+//
+//	@Summary		Get Node
+//	@Description	Get the status, conditions and allocatable resources of a node
+//	@Tags			Nodes
+//	@Param			node_name	path	string	true	"Name of node"
+//	@Success		200			object	views.NodeDetails
+//	@Failure		default		object	views.Problem
+//	@Router			/nodes/{node_name} [get]
+func getNode(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to get node"
+		ctx := r.Context()
+		nodeName := mux.Vars(r)["node_name"]
+
+		node, err := srv.GetNode(ctx, nodeName)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewNodeDetails(node))
+	})
+}
+
+// cordonNode godoc
+//
+//	@Summary		Cordon Node
+//	@Description	Mark a node unschedulable
+//	@Tags			Nodes
+//	@Param			node_name	path	string	true	"Name of node"
+//	@Param			dryRun		query	bool	false	"Validate and compute changes without applying them"
+//	@Success		200			object	views.ActionResult
+//	@Success		202			object	views.ActionResult	"Waiting for approval"
+//	@Failure		403			object	views.Problem	"Refused by policy"
+//	@Failure		409			object	views.Problem	"Another action is running on the node"
+//	@Failure		default		object	views.Problem
+//	@Router			/nodes/{node_name}/cordon [put]
+func cordonNode(srv *service.Executor) http.Handler {
+	return setUnschedulable("failed to cordon node", srv.Cordon)
+}
+
+// uncordonNode godoc
+//
+//	@Summary		Uncordon Node
+//	@Description	Mark a node schedulable
+//	@Tags			Nodes
+//	@Param			node_name	path	string	true	"Name of node"
+//	@Param			dryRun		query	bool	false	"Validate and compute changes without applying them"
+//	@Success		200			object	views.ActionResult
+//	@Success		202			object	views.ActionResult	"Waiting for approval"
+//	@Failure		403			object	views.Problem	"Refused by policy"
+//	@Failure		409			object	views.Problem	"Another action is running on the node"
+//	@Failure		default		object	views.Problem
+//	@Router			/nodes/{node_name}/uncordon [put]
+func uncordonNode(srv *service.Executor) http.Handler {
+	return setUnschedulable("failed to uncordon node", srv.Uncordon)
+}
+
+func setUnschedulable(errMsg string, action func(context.Context, string, service.ActionOptions) (*entity.ActionResult, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nodeName := mux.Vars(r)["node_name"]
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+
+		result, err := action(r.Context(), nodeName, service.ActionOptions{DryRun: dryRun})
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		writeActionResult(w, result)
+	})
+}
+
+// drainNode godoc
+//
+//	@Summary		Drain Node
+//	@Description	Cordon a node and evict its pods through the Eviction API, which honours PodDisruptionBudgets.
+//	@Description	DaemonSet, static and completed pods are skipped. Evictions blocked by a budget are retried until the timeout.
+//	@Description	Pods not managed by a controller would not be recreated, so they fail the drain unless it is forced.
+//	@Tags			Nodes
+//	@Param			node_name	path	string	true	"Name of node"
+//	@Param			dryRun		query	bool	false	"Validate and compute changes without applying them"
+//	@Param			gracePeriod	query	string	false	"Termination grace period of the evicted pods, e.g. 30s; their own when absent"
+//	@Param			timeout		query	string	false	"Maximum time to wait for the pods to be evicted, e.g. 5m; capped by the configured wait timeout"
+//	@Param			force		query	bool	false	"Evict pods not managed by a controller too, with a warning; they are not recreated"
+//	@Success		200			object	views.ActionResult	"Evicted and skipped pods"
+//	@Success		202			object	views.ActionResult	"Waiting for approval"
+//	@Failure		403			object	views.Problem	"Refused by policy"
+//	@Failure		409			object	views.Problem	"Another action is running on the node, or some pods were not evicted; pods reports the outcome per pod"
+//	@Failure		default		object	views.Problem
+//	@Router			/nodes/{node_name}/drain [put]
+func drainNode(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to drain node"
+		ctx := r.Context()
+		nodeName := mux.Vars(r)["node_name"]
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+		timeout, err := queryDuration(r, "timeout")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("timeout", err), errMsg)
+			return
+		}
+		gracePeriod, err := queryGracePeriod(r)
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		force, err := queryBool(r, "force")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("force", err), errMsg)
+			return
+		}
+
+		result, err := srv.Drain(ctx, nodeName, service.ActionOptions{
+			DryRun:      dryRun,
+			Timeout:     timeout,
+			GracePeriod: gracePeriod,
+			Force:       force,
+		})
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		writeActionResult(w, result)
+	})
+}
+
+// makeNodeRoutes mounts the node routes beside /kubernetes rather than in it,
+// where they would shadow the routes of a namespace named nodes.
+func makeNodeRoutes(r *mux.Router, srv *service.Executor) {
+	path := "/nodes"
+	serviceRouter := r.PathPrefix(path).Subrouter()
+	serviceRouter.Handle("", listNodes(srv)).Methods("GET")
+	serviceRouter.Handle("/{node_name}", getNode(srv)).Methods("GET")
+	serviceRouter.Handle("/{node_name}/cordon", cordonNode(srv)).Methods("PUT")
+	serviceRouter.Handle("/{node_name}/uncordon", uncordonNode(srv)).Methods("PUT")
+	serviceRouter.Handle("/{node_name}/drain", drainNode(srv)).Methods("PUT")
+}
@@ -14,12 +14,22 @@ import (
 func Make(app *application.Application) http.Handler {
 	r := mux.NewRouter()
 	r.PathPrefix("/docs/").Handler(httpSwagger.WrapHandler)
+	r.Handle("/metrics", app.Metrics.Handler()).Methods("GET")
+	makeHealthRoutes(r, app)
 
 	r.MethodNotAllowedHandler = handlers.NotAllowedHandler()
 	r.NotFoundHandler = handlers.NotFoundHandler()
 
 	path := "/api"
 	apiRouter := r.PathPrefix(path).Subrouter()
-	makeKubernetesRoutes(apiRouter, app)
-	return middleware.NewLogger(r)
+	makeKubernetesRoutes(apiRouter, app.ExecutorService)
+	makeNodeRoutes(apiRouter, app.ExecutorService)
+	makeApprovalRoutes(apiRouter, app.ExecutorService)
+	makeClusterRoutes(apiRouter, app)
+	var handler http.Handler = middleware.NewIdempotency(app.Config.Idempotency.TTL, r)
+	handler = middleware.NewMetrics(r, app.Metrics, handler)
+	handler = middleware.NewLogger(r, handler, healthPaths...)
+	handler = middleware.NewTracing(r, handler)
+	handler = middleware.NewPrincipal(app.Config.Auth.PrincipalHeader, handler)
+	return middleware.NewRequestID(handler)
 }
@@ -0,0 +1,120 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/problem"
+	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/views"
+)
+
+// coreGroup names the core API group, which is empty, in paths.
+const coreGroup = "core"
+
+// workloadVar reads the workload addressed by the group, kind and name path
+// variables.
+func workloadVar(r *http.Request) entity.Workload {
+	vars := mux.Vars(r)
+	group := vars["group"]
+	if group == coreGroup {
+		group = ""
+	}
+	return entity.Workload{Group: group, Kind: vars["kind"], Name: vars["name"]}
+}
+
+// getScale godoc
+//
+//	@Summary		Get Scale
+//	@Description	Get the replicas of a workload of any kind exposing the scale subresource
+//	@Tags			Scale
+//	@Param			namespace	path	string	true	"Name of namespace"
+//	@Param			group		path	string	true	"API group of the kind, e.g. apps or argoproj.io; core for the core group"
+//	@Param			kind		path	string	true	"Kind or resource name, e.g. StatefulSet or statefulsets"
+//	@Param			name		path	string	true	"Name of the workload"
+//	@Success		200			object	views.Scale
+//	@Failure		default		object	views.Problem
+//	@Router			/kubernetes/{namespace}/scale/{group}/{kind}/{name} [get]
+func getScale(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to get scale"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+
+		sc, err := srv.GetScale(ctx, namespace, workloadVar(r))
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(views.NewScale(sc))
+	})
+}
+
+// scaleWorkload godoc
+//
+//	@Summary		Scale Workload
+//	@Description	Scale a workload of any kind exposing the scale subresource, e.g. a StatefulSet or an Argo Rollout
+//	@Tags			Scale
+//	@Param			namespace	path	string	true	"Name of namespace"
+//	@Param			group		path	string	true	"API group of the kind, e.g. apps or argoproj.io; core for the core group"
+//	@Param			kind		path	string	true	"Kind or resource name, e.g. StatefulSet or statefulsets"
+//	@Param			name		path	string	true	"Name of the workload"
+//	@Param			replicas	query	string	true	"Amount of Replicas"
+//	@Param			dryRun		query	bool	false	"Validate and compute changes without applying them"
+//	@Param			timeout		query	string	false	"Maximum time to wait for the workload to be scaled, e.g. 90s; capped by the configured wait timeout"
+//	@Param			force		query	bool	false	"Scale even though a horizontal pod autoscaler owns the replicas or a PodDisruptionBudget requires more healthy pods, with a warning"
+//	@Success		200			object	views.ActionResult
+//	@Success		202			object	views.ActionResult	"Waiting for approval"
+//	@Failure		400			object	views.Problem	"Unknown kind, or the kind is not scalable"
+//	@Failure		403			object	views.Problem	"Refused by policy"
+//	@Failure		409			object	views.Problem	"Another action is running on the workload, a horizontal pod autoscaler owns its replicas, or a PodDisruptionBudget requires more healthy pods"
+//	@Failure		504			object	views.Problem	"The workload was not scaled in time"
+//	@Failure		default		object	views.Problem
+//	@Router			/kubernetes/{namespace}/scale/{group}/{kind}/{name} [put]
+func scaleWorkload(srv *service.Executor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errMsg := "failed to scale"
+		ctx := r.Context()
+		namespace := mux.Vars(r)["namespace"]
+
+		targetReplicas, err := strconv.ParseInt(r.URL.Query().Get("replicas"), 10, 32)
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("replicas", err), errMsg)
+			return
+		}
+		dryRun, err := queryBool(r, "dryRun")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("dryRun", err), errMsg)
+			return
+		}
+		timeout, err := queryDuration(r, "timeout")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("timeout", err), errMsg)
+			return
+		}
+		force, err := queryBool(r, "force")
+		if err != nil {
+			problem.WriteError(w, r, invalidArgument("force", err), errMsg)
+			return
+		}
+
+		result, err := srv.ScaleWorkload(ctx, namespace, workloadVar(r), int32(targetReplicas),
+			service.ActionOptions{DryRun: dryRun, Timeout: timeout, Force: force})
+		if err != nil {
+			problem.WriteError(w, r, err, errMsg)
+			return
+		}
+		writeActionResult(w, result)
+	})
+}
+
+func makeScaleRoutes(r *mux.Router, srv *service.Executor) {
+	r.Handle("/{namespace}/scale/{group}/{kind}/{name}", getScale(srv)).Methods("GET")
+	r.Handle("/{namespace}/scale/{group}/{kind}/{name}", scaleWorkload(srv)).Methods("PUT")
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/inviewteam/fenrir.executor/internal/application"
 	"github.com/inviewteam/fenrir.executor/internal/infrastructure/http/routes"
+	log "github.com/sirupsen/logrus"
 )
 
 //	@title			Swagger Backend API
@@ -22,11 +23,17 @@ import (
 // @host		127.0.0.1:30000
 // @BasePath	/api
 type Server struct {
-	srv http.Server
+	srv         http.Server
+	app         *application.Application
+	gracePeriod time.Duration
 }
 
 type Config struct {
+	Address string        `yaml:"address"`
 	Timeout TimeoutConfig `yaml:"timeout,omitempty"`
+	// GracePeriod is how long a shutdown waits for running requests and
+	// operations before interrupting them.
+	GracePeriod time.Duration `yaml:"gracePeriod"`
 }
 
 type TimeoutConfig struct {
@@ -37,42 +44,75 @@ type TimeoutConfig struct {
 
 var (
 	DefaultConfig = Config{
+		Address: ":30000",
 		Timeout: TimeoutConfig{
 			Idle:  time.Second * 30,
 			Read:  time.Second * 30,
-			Write: time.Second * 30,
+			Write: time.Minute * 6, // outlasts the executor wait timeout
 		},
+		GracePeriod: time.Second * 25,
 	}
 )
 
-func NewServer(app *application.Application) *Server {
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Address == "" {
+		errs = append(errs, errors.New("server.address is required"))
+	}
+	if c.Timeout.Idle <= 0 || c.Timeout.Read <= 0 || c.Timeout.Write <= 0 {
+		errs = append(errs, errors.New("server.timeout values must be positive"))
+	}
+	if c.GracePeriod <= 0 {
+		errs = append(errs, errors.New("server.gracePeriod must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+func NewServer(app *application.Application, cfg Config) *Server {
 	return &Server{
 		srv: http.Server{
 			Handler:      routes.Make(app),
-			Addr:         ":30000",
-			IdleTimeout:  DefaultConfig.Timeout.Idle,
-			ReadTimeout:  DefaultConfig.Timeout.Read,
-			WriteTimeout: DefaultConfig.Timeout.Write,
+			Addr:         cfg.Address,
+			IdleTimeout:  cfg.Timeout.Idle,
+			ReadTimeout:  cfg.Timeout.Read,
+			WriteTimeout: cfg.Timeout.Write,
 		},
+		app:         app,
+		gracePeriod: cfg.GracePeriod,
 	}
 }
 
-func (s *Server) Start(ctx context.Context) {
+// Start serves requests until a shutdown signal arrives or ctx is done. On
+// shutdown it stops accepting requests, then waits up to the grace period for
+// running ones. It returns an error when the server fails to serve.
+func (s *Server) Start(ctx context.Context) error {
+	serveErr := make(chan error, 1)
 	go func() {
-		listener := make(chan os.Signal, 1)
-		signal.Notify(listener, os.Interrupt, syscall.SIGTERM)
-		fmt.Println("Received a shutdown signal:", <-listener)
-		// Listen on application shutdown signals.
-
-		// Shutdown HTTP server.
-		if err := s.srv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			fmt.Printf("Failed to shutdown: %s", err)
-		}
+		log.Info("Listening on ", s.srv.Addr)
+		serveErr <- s.srv.ListenAndServe()
 	}()
 
-	fmt.Println("Listening on ", s.srv.Addr)
-	// Start HTTP server.
-	if err := s.srv.ListenAndServe(); err != nil {
-		fmt.Printf("Failed to listen and serve: %s", err)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("failed to listen and serve: %w", err)
+	case sig := <-signals:
+		log.Info("Received a shutdown signal: ", sig)
+	case <-ctx.Done():
+		log.Info("Shutting down: ", ctx.Err())
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.gracePeriod)
+	defer cancel()
+	if err := s.srv.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("Requests still running after %s: %v", s.gracePeriod, err)
+	}
+	if err := s.app.Shutdown(shutdownCtx); err != nil {
+		log.Errorf("Failed to record interrupted operations: %v", err)
 	}
+	log.Info("Server stopped")
+	return nil
 }
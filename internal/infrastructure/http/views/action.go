@@ -0,0 +1,85 @@
+package views
+
+import (
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+type ActionResult struct {
+	DryRun  bool      `json:"dryRun"`
+	Changes []*Change `json:"changes"`
+	// Warnings report safeguards the action was forced through.
+	Warnings []string  `json:"warnings,omitempty"`
+	Approval *Approval `json:"approval,omitempty"`
+	// Pods reports the outcome for each pod of an action acting on several.
+	Pods []*PodResult `json:"pods,omitempty"`
+	// Replacement is the ready pod that replaced a restarted one.
+	Replacement *Replacement `json:"replacement,omitempty"`
+}
+
+type Replacement struct {
+	Name        string `json:"name"`
+	Node        string `json:"node"`
+	TimeToReady string `json:"timeToReady"`
+}
+
+type PodResult struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Outcome   string `json:"outcome" enums:"evicted,restarted,skipped,failed"`
+	Reason    string `json:"reason,omitempty"`
+	Duration  string `json:"duration"`
+	// Replacement is the ready pod that replaced a restarted one.
+	Replacement *Replacement `json:"replacement,omitempty"`
+}
+
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func NewActionResult(e *entity.ActionResult) *ActionResult {
+	changes := make([]*Change, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		changes = append(changes, &Change{
+			Field: c.Field,
+			From:  c.From,
+			To:    c.To,
+		})
+	}
+	result := &ActionResult{DryRun: e.DryRun, Changes: changes, Warnings: e.Warnings}
+	if e.Approval != nil {
+		result.Approval = NewApproval(e.Approval)
+	}
+	result.Replacement = newReplacement(e.Replacement)
+	result.Pods = NewPodResults(e.Pods)
+	return result
+}
+
+func NewPodResults(e []*entity.PodResult) []*PodResult {
+	var pods []*PodResult
+	for _, pod := range e {
+		pods = append(pods, &PodResult{
+			Namespace:   pod.Namespace,
+			Name:        pod.Name,
+			Outcome:     string(pod.Outcome),
+			Reason:      pod.Reason,
+			Duration:    pod.Duration.Round(time.Millisecond).String(),
+			Replacement: newReplacement(pod.Replacement),
+		})
+	}
+	return pods
+}
+
+func newReplacement(e *entity.Replacement) *Replacement {
+	if e == nil {
+		return nil
+	}
+	return &Replacement{
+		Name:        e.Name,
+		Node:        e.Node,
+		TimeToReady: e.TimeToReady.Round(time.Millisecond).String(),
+	}
+}
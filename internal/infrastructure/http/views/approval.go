@@ -0,0 +1,56 @@
+package views
+
+import (
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+type Approval struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Rule      string `json:"rule"`
+	Action    string `json:"action"`
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Kind is the kind of the workload a scale targets, e.g. StatefulSet.apps.
+	Kind           string     `json:"kind,omitempty"`
+	TargetReplicas *int32     `json:"targetReplicas,omitempty"`
+	RequestedBy    string     `json:"requestedBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	DecidedBy      string     `json:"decidedBy,omitempty"`
+	DecidedAt      *time.Time `json:"decidedAt,omitempty"`
+	Error          string     `json:"error,omitempty"`
+}
+
+func NewApproval(e *entity.Approval) *Approval {
+	approval := &Approval{
+		ID:          e.ID,
+		Status:      string(e.Status),
+		Rule:        e.Rule,
+		Action:      string(e.Action.Kind),
+		Cluster:     e.Action.Cluster,
+		Namespace:   e.Action.Namespace,
+		Name:        e.Action.Name,
+		RequestedBy: e.RequestedBy,
+		CreatedAt:   e.CreatedAt,
+		ExpiresAt:   e.ExpiresAt,
+		DecidedBy:   e.DecidedBy,
+		Error:       e.Error,
+	}
+	if e.Action.Kind == entity.ActionScale {
+		approval.TargetReplicas = &e.Action.TargetReplicas
+	}
+	if e.Action.Workload != nil {
+		approval.Kind = e.Action.Workload.Kind
+		if e.Action.Workload.Group != "" {
+			approval.Kind += "." + e.Action.Workload.Group
+		}
+	}
+	if !e.DecidedAt.IsZero() {
+		approval.DecidedAt = &e.DecidedAt
+	}
+	return approval
+}
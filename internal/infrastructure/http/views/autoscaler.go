@@ -0,0 +1,78 @@
+package views
+
+import (
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+type HorizontalPodAutoscaler struct {
+	Namespace       string                 `json:"namespace"`
+	Name            string                 `json:"name"`
+	Target          *AutoscalerTarget      `json:"target"`
+	MinReplicas     int32                  `json:"minReplicas"`
+	MaxReplicas     int32                  `json:"maxReplicas"`
+	CurrentReplicas int32                  `json:"currentReplicas"`
+	DesiredReplicas int32                  `json:"desiredReplicas"`
+	LastScaleTime   *time.Time             `json:"lastScaleTime,omitempty"`
+	Metrics         []*AutoscalerMetric    `json:"metrics"`
+	Conditions      []*AutoscalerCondition `json:"conditions"`
+	Labels          map[string]string      `json:"labels,omitempty"`
+	Age             string                 `json:"age"`
+}
+
+type AutoscalerTarget struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type AutoscalerMetric struct {
+	Type    string `json:"type" enums:"Resource,ContainerResource,Pods,Object,External"`
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	Current string `json:"current,omitempty"`
+}
+
+type AutoscalerCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+func NewHorizontalPodAutoscaler(e *entity.HorizontalPodAutoscaler) *HorizontalPodAutoscaler {
+	metrics := make([]*AutoscalerMetric, 0, len(e.Metrics))
+	for _, metric := range e.Metrics {
+		metrics = append(metrics, &AutoscalerMetric{
+			Type:    metric.Type,
+			Name:    metric.Name,
+			Target:  metric.Target,
+			Current: metric.Current,
+		})
+	}
+	conditions := make([]*AutoscalerCondition, 0, len(e.Conditions))
+	for _, condition := range e.Conditions {
+		conditions = append(conditions, &AutoscalerCondition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime,
+		})
+	}
+	return &HorizontalPodAutoscaler{
+		Namespace:       e.Namespace,
+		Name:            e.Name,
+		Target:          &AutoscalerTarget{Kind: e.TargetKind, Name: e.TargetName},
+		MinReplicas:     e.MinReplicas,
+		MaxReplicas:     e.MaxReplicas,
+		CurrentReplicas: e.CurrentReplicas,
+		DesiredReplicas: e.DesiredReplicas,
+		LastScaleTime:   e.LastScaleTime,
+		Metrics:         metrics,
+		Conditions:      conditions,
+		Labels:          e.Labels,
+		Age:             e.Age.String(),
+	}
+}
@@ -0,0 +1,11 @@
+package views
+
+type Cluster struct {
+	Name          string `json:"name"`
+	Default       bool   `json:"default"`
+	Host          string `json:"host"`
+	Namespace     string `json:"namespace"`
+	Connected     bool   `json:"connected"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
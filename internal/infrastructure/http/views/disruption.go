@@ -0,0 +1,35 @@
+package views
+
+import "github.com/inviewteam/fenrir.executor/internal/domain/entity"
+
+type DisruptionBudget struct {
+	Namespace          string `json:"namespace"`
+	Name               string `json:"name"`
+	MinAvailable       string `json:"minAvailable,omitempty"`
+	MaxUnavailable     string `json:"maxUnavailable,omitempty"`
+	CurrentHealthy     int32  `json:"currentHealthy"`
+	DesiredHealthy     int32  `json:"desiredHealthy"`
+	ExpectedPods       int32  `json:"expectedPods"`
+	DisruptionsAllowed int32  `json:"disruptionsAllowed"`
+}
+
+type DisruptionBudgetList struct {
+	Items []*DisruptionBudget `json:"items"`
+}
+
+func NewDisruptionBudgetList(e []*entity.DisruptionBudget) *DisruptionBudgetList {
+	items := make([]*DisruptionBudget, 0, len(e))
+	for _, budget := range e {
+		items = append(items, &DisruptionBudget{
+			Namespace:          budget.Namespace,
+			Name:               budget.Name,
+			MinAvailable:       budget.MinAvailable,
+			MaxUnavailable:     budget.MaxUnavailable,
+			CurrentHealthy:     budget.CurrentHealthy,
+			DesiredHealthy:     budget.DesiredHealthy,
+			ExpectedPods:       budget.ExpectedPods,
+			DisruptionsAllowed: budget.DisruptionsAllowed,
+		})
+	}
+	return &DisruptionBudgetList{Items: items}
+}
@@ -0,0 +1,18 @@
+package views
+
+type Readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]*Check `json:"checks"`
+}
+
+type Check struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type Version struct {
+	Commit        string `json:"commit"`
+	GoVersion     string `json:"goVersion"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
@@ -1,20 +1,53 @@
 package views
 
 import (
+	"time"
+
 	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
 )
 
 type Pod struct {
-	Name       string       `json:"name"`
-	Status     string       `json:"status"`
-	Restarts   int          `json:"restarts"`
-	Age        string       `json:"age"`
-	Containers []*Container `json:"containers"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Restarts int    `json:"restarts"`
+	Age      string `json:"age"`
+	Node     string `json:"node,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	Ready    bool   `json:"ready"`
+	// Revision is the deployment revision running in the pod; it is only
+	// set when listing the pods of a deployment.
+	Revision string `json:"revision,omitempty"`
+	// DisruptionsAllowed is the fewest disruptions the PodDisruptionBudgets
+	// covering the pod allow; absent when none covers it.
+	DisruptionsAllowed *int32       `json:"disruptionsAllowed,omitempty"`
+	Containers         []*Container `json:"containers"`
+	Freshness          *Freshness   `json:"freshness,omitempty"`
+}
+
+// Freshness tells whether a read was served by the informer cache and when
+// its data was last known to match the cluster.
+type Freshness struct {
+	Source     string    `json:"source" enums:"cache,live"`
+	ObservedAt time.Time `json:"observedAt"`
+}
+
+func NewFreshness(e entity.Freshness) *Freshness {
+	if e.ObservedAt.IsZero() {
+		return nil
+	}
+	source := "live"
+	if e.Cached {
+		source = "cache"
+	}
+	return &Freshness{Source: source, ObservedAt: e.ObservedAt}
 }
 
 type Container struct {
 	Name         string `json:"name"`
 	State        string `json:"state"`
+	Reason       string `json:"reason,omitempty"`
+	Ready        bool   `json:"ready"`
+	Restarts     int    `json:"restarts"`
 	CpuUsage     int64  `json:"cpuUsage"`
 	MemoryUsage  int64  `json:"memoryUsage"`
 	CpuLimits    int64  `json:"cpuLimits"`
@@ -23,16 +56,24 @@ type Container struct {
 
 func NewPod(e *entity.Pod) *Pod {
 	return &Pod{
-		Name:     e.Name,
-		Status:   e.Status,
-		Restarts: e.Restarts,
-		Age:      e.Age.String(),
+		Name:               e.Name,
+		Status:             e.Status,
+		Restarts:           e.Restarts,
+		Age:                e.Age.String(),
+		Node:               e.Node,
+		IP:                 e.IP,
+		Ready:              e.Ready,
+		Revision:           e.Revision,
+		DisruptionsAllowed: e.DisruptionsAllowed,
 		Containers: func() []*Container {
 			res := make([]*Container, 0, len(e.Containers))
 			for _, cr := range e.Containers {
 				res = append(res, &Container{
 					Name:         cr.Name,
 					State:        cr.State,
+					Reason:       cr.Reason,
+					Ready:        cr.Ready,
+					Restarts:     cr.Restarts,
 					CpuUsage:     cr.CpuUsage,
 					MemoryUsage:  cr.MemoryUsage,
 					CpuLimits:    cr.CpuLimits,
@@ -41,30 +82,47 @@ func NewPod(e *entity.Pod) *Pod {
 			}
 			return res
 		}(),
+		Freshness: NewFreshness(e.Freshness),
 	}
 }
 
 type DeploymentPods struct {
-	Pods []DeploymentPod `json:"pods"`
-}
-
-type DeploymentPod struct {
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	Pods      []*Pod     `json:"pods"`
+	Freshness *Freshness `json:"freshness,omitempty"`
 }
 
 func NewPods(podEntities []*entity.Pod) *DeploymentPods {
-	pods := make([]DeploymentPod, 0, len(podEntities))
+	pods := make([]*Pod, 0, len(podEntities))
 	for _, p := range podEntities {
-		pods = append(pods, DeploymentPod{
-			Name:   p.Name,
-			Status: p.Status,
-		})
+		pod := NewPod(p)
+		pod.Freshness = nil
+		pods = append(pods, pod)
 	}
-	return &DeploymentPods{Pods: pods}
+	res := &DeploymentPods{Pods: pods}
+	if len(podEntities) > 0 {
+		res.Freshness = NewFreshness(podEntities[0].Freshness)
+	}
+	return res
 }
 
 type Deployment struct {
-	Name     string `json:"name"`
-	Replicas int32  `json:"replicas"`
+	Name            string `json:"name"`
+	Replicas        int32  `json:"replicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	ReadyReplicas   int32  `json:"readyReplicas"`
+	// DisruptionsAllowed is the fewest disruptions the PodDisruptionBudgets
+	// covering the pods allow; absent when none covers them.
+	DisruptionsAllowed *int32     `json:"disruptionsAllowed,omitempty"`
+	Freshness          *Freshness `json:"freshness,omitempty"`
+}
+
+func NewDeployment(e *entity.Deployment) *Deployment {
+	return &Deployment{
+		Name:               e.Name,
+		Replicas:           e.Replicas,
+		CurrentReplicas:    e.CurrentReplicas,
+		ReadyReplicas:      e.ReadyReplicas,
+		DisruptionsAllowed: e.DisruptionsAllowed,
+		Freshness:          NewFreshness(e.Freshness),
+	}
 }
@@ -0,0 +1,220 @@
+package views
+
+import (
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+// The list views hold one page of objects. Continue is passed back as the
+// continue query parameter to fetch the next page; it is empty on the last.
+
+type NamespaceList struct {
+	Items     []*Namespace `json:"items"`
+	Continue  string       `json:"continue,omitempty"`
+	Freshness *Freshness   `json:"freshness,omitempty"`
+}
+
+type NodeList struct {
+	Items     []*Node    `json:"items"`
+	Continue  string     `json:"continue,omitempty"`
+	Freshness *Freshness `json:"freshness,omitempty"`
+}
+
+type PodList struct {
+	Items     []*Pod     `json:"items"`
+	Continue  string     `json:"continue,omitempty"`
+	Freshness *Freshness `json:"freshness,omitempty"`
+}
+
+type DeploymentList struct {
+	Items     []*Deployment `json:"items"`
+	Continue  string        `json:"continue,omitempty"`
+	Freshness *Freshness    `json:"freshness,omitempty"`
+}
+
+type StatefulSetList struct {
+	Items     []*StatefulSet `json:"items"`
+	Continue  string         `json:"continue,omitempty"`
+	Freshness *Freshness     `json:"freshness,omitempty"`
+}
+
+type ServiceList struct {
+	Items     []*Service `json:"items"`
+	Continue  string     `json:"continue,omitempty"`
+	Freshness *Freshness `json:"freshness,omitempty"`
+}
+
+type Namespace struct {
+	Name   string            `json:"name"`
+	Status string            `json:"status"`
+	Age    string            `json:"age"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type Node struct {
+	Name           string            `json:"name"`
+	Ready          bool              `json:"ready"`
+	Unschedulable  bool              `json:"unschedulable"`
+	Roles          []string          `json:"roles"`
+	KubeletVersion string            `json:"kubeletVersion"`
+	Age            string            `json:"age"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// NodeDetails is a node with its conditions and resources.
+type NodeDetails struct {
+	Node
+	Conditions  []*NodeCondition  `json:"conditions"`
+	Capacity    map[string]string `json:"capacity"`
+	Allocatable map[string]string `json:"allocatable"`
+}
+
+type NodeCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason,omitempty"`
+	Message            string    `json:"message,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+func NewNodeDetails(e *entity.Node) *NodeDetails {
+	conditions := make([]*NodeCondition, 0, len(e.Conditions))
+	for _, condition := range e.Conditions {
+		conditions = append(conditions, &NodeCondition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime,
+		})
+	}
+	return &NodeDetails{
+		Node:        *NewNode(e),
+		Conditions:  conditions,
+		Capacity:    e.Capacity,
+		Allocatable: e.Allocatable,
+	}
+}
+
+type StatefulSet struct {
+	Name            string            `json:"name"`
+	Replicas        int32             `json:"replicas"`
+	CurrentReplicas int32             `json:"currentReplicas"`
+	ReadyReplicas   int32             `json:"readyReplicas"`
+	Age             string            `json:"age"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+type Service struct {
+	Name      string            `json:"name"`
+	Type      string            `json:"type"`
+	ClusterIP string            `json:"clusterIP"`
+	Ports     []ServicePort     `json:"ports"`
+	Age       string            `json:"age"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+type ServicePort struct {
+	Name       string `json:"name,omitempty"`
+	Protocol   string `json:"protocol"`
+	Port       int32  `json:"port"`
+	TargetPort string `json:"targetPort"`
+}
+
+func NewNamespaceList(e *entity.List[*entity.Namespace]) *NamespaceList {
+	items := make([]*Namespace, 0, len(e.Items))
+	for _, namespace := range e.Items {
+		items = append(items, &Namespace{
+			Name:   namespace.Name,
+			Status: namespace.Status,
+			Age:    namespace.Age.String(),
+			Labels: namespace.Labels,
+		})
+	}
+	return &NamespaceList{Items: items, Continue: e.Continue, Freshness: NewFreshness(e.Freshness)}
+}
+
+func NewNode(e *entity.Node) *Node {
+	roles := e.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return &Node{
+		Name:           e.Name,
+		Ready:          e.Ready,
+		Unschedulable:  e.Unschedulable,
+		Roles:          roles,
+		KubeletVersion: e.KubeletVersion,
+		Age:            e.Age.String(),
+		Labels:         e.Labels,
+	}
+}
+
+func NewNodeList(e *entity.List[*entity.Node]) *NodeList {
+	items := make([]*Node, 0, len(e.Items))
+	for _, node := range e.Items {
+		items = append(items, NewNode(node))
+	}
+	return &NodeList{Items: items, Continue: e.Continue, Freshness: NewFreshness(e.Freshness)}
+}
+
+func NewPodList(e *entity.List[*entity.Pod]) *PodList {
+	items := make([]*Pod, 0, len(e.Items))
+	for _, pod := range e.Items {
+		view := NewPod(pod)
+		// The freshness of the page applies to each item.
+		view.Freshness = nil
+		items = append(items, view)
+	}
+	return &PodList{Items: items, Continue: e.Continue, Freshness: NewFreshness(e.Freshness)}
+}
+
+func NewDeploymentList(e *entity.List[*entity.Deployment]) *DeploymentList {
+	items := make([]*Deployment, 0, len(e.Items))
+	for _, deployment := range e.Items {
+		view := NewDeployment(deployment)
+		view.Freshness = nil
+		items = append(items, view)
+	}
+	return &DeploymentList{Items: items, Continue: e.Continue, Freshness: NewFreshness(e.Freshness)}
+}
+
+func NewStatefulSetList(e *entity.List[*entity.StatefulSet]) *StatefulSetList {
+	items := make([]*StatefulSet, 0, len(e.Items))
+	for _, statefulSet := range e.Items {
+		items = append(items, &StatefulSet{
+			Name:            statefulSet.Name,
+			Replicas:        statefulSet.Replicas,
+			CurrentReplicas: statefulSet.CurrentReplicas,
+			ReadyReplicas:   statefulSet.ReadyReplicas,
+			Age:             statefulSet.Age.String(),
+			Labels:          statefulSet.Labels,
+		})
+	}
+	return &StatefulSetList{Items: items, Continue: e.Continue, Freshness: NewFreshness(e.Freshness)}
+}
+
+func NewServiceList(e *entity.List[*entity.Service]) *ServiceList {
+	items := make([]*Service, 0, len(e.Items))
+	for _, svc := range e.Items {
+		ports := make([]ServicePort, 0, len(svc.Ports))
+		for _, port := range svc.Ports {
+			ports = append(ports, ServicePort{
+				Name:       port.Name,
+				Protocol:   port.Protocol,
+				Port:       port.Port,
+				TargetPort: port.TargetPort,
+			})
+		}
+		items = append(items, &Service{
+			Name:      svc.Name,
+			Type:      svc.Type,
+			ClusterIP: svc.ClusterIP,
+			Ports:     ports,
+			Age:       svc.Age.String(),
+			Labels:    svc.Labels,
+		})
+	}
+	return &ServiceList{Items: items, Continue: e.Continue, Freshness: NewFreshness(e.Freshness)}
+}
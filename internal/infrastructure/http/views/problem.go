@@ -0,0 +1,20 @@
+package views
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code identifies the problem for machines and does not change between
+	// releases.
+	Code string `json:"code"`
+	// Reason is the reason reported by the Kubernetes API when it refused
+	// the request.
+	Reason    string `json:"reason,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+	// Pods reports the outcome for each pod of an action that failed on
+	// some of them.
+	Pods []*PodResult `json:"pods,omitempty"`
+}
@@ -0,0 +1,24 @@
+package views
+
+import "github.com/inviewteam/fenrir.executor/internal/domain/entity"
+
+type Scale struct {
+	Group           string `json:"group"`
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	Replicas        int32  `json:"replicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	// Selector selects the pods of the workload.
+	Selector string `json:"selector,omitempty"`
+}
+
+func NewScale(e *entity.Scale) *Scale {
+	return &Scale{
+		Group:           e.Workload.Group,
+		Kind:            e.Workload.Kind,
+		Name:            e.Workload.Name,
+		Replicas:        e.Replicas,
+		CurrentReplicas: e.CurrentReplicas,
+		Selector:        e.Selector,
+	}
+}
@@ -0,0 +1,169 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+)
+
+func newAutoscaler(hpa *autoscalingv2.HorizontalPodAutoscaler) *entity.HorizontalPodAutoscaler {
+	eHPA := &entity.HorizontalPodAutoscaler{
+		Namespace:       hpa.Namespace,
+		Name:            hpa.Name,
+		Labels:          hpa.Labels,
+		TargetKind:      hpa.Spec.ScaleTargetRef.Kind,
+		TargetName:      hpa.Spec.ScaleTargetRef.Name,
+		MinReplicas:     1,
+		MaxReplicas:     hpa.Spec.MaxReplicas,
+		CurrentReplicas: hpa.Status.CurrentReplicas,
+		DesiredReplicas: hpa.Status.DesiredReplicas,
+		Age:             age(hpa),
+	}
+	if hpa.Spec.MinReplicas != nil {
+		eHPA.MinReplicas = *hpa.Spec.MinReplicas
+	}
+	if hpa.Status.LastScaleTime != nil {
+		eHPA.LastScaleTime = &hpa.Status.LastScaleTime.Time
+	}
+	current := make(map[string]string, len(hpa.Status.CurrentMetrics))
+	for _, metric := range hpa.Status.CurrentMetrics {
+		name, value := metricStatus(metric)
+		current[string(metric.Type)+"/"+name] = value
+	}
+	for _, metric := range hpa.Spec.Metrics {
+		name, target := metricSpec(metric)
+		eHPA.Metrics = append(eHPA.Metrics, &entity.AutoscalerMetric{
+			Type:    string(metric.Type),
+			Name:    name,
+			Target:  target,
+			Current: current[string(metric.Type)+"/"+name],
+		})
+	}
+	for _, condition := range hpa.Status.Conditions {
+		eHPA.Conditions = append(eHPA.Conditions, &entity.AutoscalerCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	return eHPA
+}
+
+// metricSpec returns the name of the metric and its formatted target.
+func metricSpec(metric autoscalingv2.MetricSpec) (string, string) {
+	switch {
+	case metric.Resource != nil:
+		return string(metric.Resource.Name), metricTarget(metric.Resource.Target)
+	case metric.ContainerResource != nil:
+		return metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name), metricTarget(metric.ContainerResource.Target)
+	case metric.Pods != nil:
+		return metric.Pods.Metric.Name, metricTarget(metric.Pods.Target)
+	case metric.Object != nil:
+		return metric.Object.Metric.Name, metricTarget(metric.Object.Target)
+	case metric.External != nil:
+		return metric.External.Metric.Name, metricTarget(metric.External.Target)
+	}
+	return "", ""
+}
+
+// metricStatus returns the name of the metric and its formatted value.
+func metricStatus(metric autoscalingv2.MetricStatus) (string, string) {
+	switch {
+	case metric.Resource != nil:
+		return string(metric.Resource.Name), metricValue(metric.Resource.Current)
+	case metric.ContainerResource != nil:
+		return metric.ContainerResource.Container + "/" + string(metric.ContainerResource.Name), metricValue(metric.ContainerResource.Current)
+	case metric.Pods != nil:
+		return metric.Pods.Metric.Name, metricValue(metric.Pods.Current)
+	case metric.Object != nil:
+		return metric.Object.Metric.Name, metricValue(metric.Object.Current)
+	case metric.External != nil:
+		return metric.External.Metric.Name, metricValue(metric.External.Current)
+	}
+	return "", ""
+}
+
+func metricTarget(target autoscalingv2.MetricTarget) string {
+	switch {
+	case target.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *target.AverageUtilization)
+	case target.AverageValue != nil:
+		return target.AverageValue.String()
+	case target.Value != nil:
+		return target.Value.String()
+	}
+	return ""
+}
+
+func metricValue(value autoscalingv2.MetricValueStatus) string {
+	switch {
+	case value.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *value.AverageUtilization)
+	case value.AverageValue != nil:
+		return value.AverageValue.String()
+	case value.Value != nil:
+		return value.Value.String()
+	}
+	return ""
+}
+
+func (r *Repository) GetHorizontalPodAutoscaler(ctx context.Context, namespace, name string) (*entity.HorizontalPodAutoscaler, error) {
+	hpa, err := r.client.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, service.ErrAutoscalerNotFound
+		}
+		return nil, fmt.Errorf("failed to get horizontal pod autoscaler: %w", err)
+	}
+	return newAutoscaler(hpa), nil
+}
+
+func (r *Repository) FindHorizontalPodAutoscaler(ctx context.Context, namespace string, target entity.Workload) (*entity.HorizontalPodAutoscaler, error) {
+	list, err := r.client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)
+	}
+	for i := range list.Items {
+		ref := list.Items[i].Spec.ScaleTargetRef
+		if ref.Kind != target.Kind || ref.Name != target.Name {
+			continue
+		}
+		// Kinds of different API groups may share a name, e.g. a custom
+		// resource named Deployment.
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err == nil && gv.Group == target.Group {
+			return newAutoscaler(&list.Items[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *Repository) SetAutoscalerLimits(ctx context.Context, namespace, name string, minReplicas, maxReplicas int32, dryRun bool) error {
+	hpaClient := r.client.AutoscalingV2().HorizontalPodAutoscalers(namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		hpa, err := hpaClient.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		hpa.Spec.MinReplicas = &minReplicas
+		hpa.Spec.MaxReplicas = maxReplicas
+		_, err = hpaClient.Update(ctx, hpa, metav1.UpdateOptions{DryRun: dryRunOption(dryRun)})
+		return err
+	})
+	if kerrors.IsNotFound(err) {
+		return service.ErrAutoscalerNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update horizontal pod autoscaler: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// cacheSyncTimeout bounds the initial listing of the informers, which never
+// completes when the executor may not list or watch a resource.
+const cacheSyncTimeout = time.Minute
+
+type CacheConfig struct {
+	// Enabled serves reads of pods, deployments, replica sets, events and
+	// pod disruption budgets from shared informers instead of the API
+	// server. Mutations and safety checks always go to the API server.
+	Enabled bool `yaml:"enabled"`
+	// Namespaces are the namespaces to watch; empty watches all of them.
+	// Reads in other namespaces go to the API server.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	// Resync is how often informers replay their content; zero disables it.
+	Resync time.Duration `yaml:"resync,omitempty"`
+}
+
+// informerCache holds one set of informers per watched namespace, or a
+// single set for all namespaces.
+type informerCache struct {
+	namespaces map[string]*namespaceCache
+}
+
+type namespaceCache struct {
+	pods        corelisters.PodLister
+	deployments appslisters.DeploymentLister
+	replicaSets appslisters.ReplicaSetLister
+	events      corelisters.EventLister
+	budgets     policylisters.PodDisruptionBudgetLister
+	// observedAt is the Unix time in nanoseconds of the last notification
+	// from any of the informers.
+	observedAt atomic.Int64
+}
+
+func newInformerCache(ctx context.Context, client kubernetes.Interface, cfg CacheConfig) (*informerCache, error) {
+	namespaces := cfg.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, cacheSyncTimeout)
+	defer cancel()
+
+	c := &informerCache{namespaces: make(map[string]*namespaceCache, len(namespaces))}
+	for _, namespace := range namespaces {
+		factory := informers.NewSharedInformerFactoryWithOptions(client, cfg.Resync, informers.WithNamespace(namespace))
+		nc := &namespaceCache{
+			pods:        factory.Core().V1().Pods().Lister(),
+			deployments: factory.Apps().V1().Deployments().Lister(),
+			replicaSets: factory.Apps().V1().ReplicaSets().Lister(),
+			events:      factory.Core().V1().Events().Lister(),
+			budgets:     factory.Policy().V1().PodDisruptionBudgets().Lister(),
+		}
+		handler := cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(any) { nc.touch() },
+			UpdateFunc: func(any, any) { nc.touch() },
+			DeleteFunc: func(any) { nc.touch() },
+		}
+		for _, informer := range []cache.SharedIndexInformer{
+			factory.Core().V1().Pods().Informer(),
+			factory.Apps().V1().Deployments().Informer(),
+			factory.Apps().V1().ReplicaSets().Informer(),
+			factory.Core().V1().Events().Informer(),
+			factory.Policy().V1().PodDisruptionBudgets().Informer(),
+		} {
+			if _, err := informer.AddEventHandler(handler); err != nil {
+				return nil, fmt.Errorf("failed to watch namespace %q: %w", namespace, err)
+			}
+		}
+
+		factory.Start(ctx.Done())
+		for informerType, synced := range factory.WaitForCacheSync(syncCtx.Done()) {
+			if !synced {
+				return nil, fmt.Errorf("failed to sync %s cache of namespace %q within %s", informerType, namespace, cacheSyncTimeout)
+			}
+		}
+		nc.touch()
+		c.namespaces[namespace] = nc
+		log.Infof("Cache of namespace %q synced", namespace)
+	}
+	return c, nil
+}
+
+// lookup returns the cache serving namespace, or nil when it is not watched.
+func (c *informerCache) lookup(namespace string) *namespaceCache {
+	if c == nil {
+		return nil
+	}
+	if nc, ok := c.namespaces[metav1.NamespaceAll]; ok {
+		return nc
+	}
+	return c.namespaces[namespace]
+}
+
+func (c *namespaceCache) touch() {
+	c.observedAt.Store(time.Now().UnixNano())
+}
+
+func (c *namespaceCache) freshness() entity.Freshness {
+	return entity.Freshness{Cached: true, ObservedAt: time.Unix(0, c.observedAt.Load())}
+}
+
+// liveFreshness describes a read served by the API server.
+func liveFreshness() entity.Freshness {
+	return entity.Freshness{ObservedAt: time.Now()}
+}
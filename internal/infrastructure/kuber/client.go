@@ -3,9 +3,11 @@ package kuber
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
@@ -13,73 +15,189 @@ import (
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/util/retry"
 
 	log "github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
 type Repository struct {
 	client  *kubernetes.Clientset
 	mClient *metrics.Clientset
+	// mapper, scales and metadata address workloads of any kind by their
+	// group and kind, through discovery.
+	mapper     *restmapper.DeferredDiscoveryRESTMapper
+	scaleKinds scale.ScaleKindResolver
+	scales     scale.ScalesGetter
+	metadata   metadata.Interface
+	// cache serves reads when enabled; it is nil otherwise.
+	cache *informerCache
 }
 
-func New(config *rest.Config) (*Repository, error) {
+// New connects to the cluster. When the cache is enabled it blocks until the
+// informers are synced; they stop when ctx is done.
+func New(ctx context.Context, config *rest.Config, cacheCfg CacheConfig) (*Repository, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
 	metricsClient, err := metrics.NewForConfig(config)
 	if err != nil {
-		log.Fatalf("Failed to create metrics client: %w", err)
+		log.Fatalf("Failed to create metrics client: %v", err)
+	}
+	r := &Repository{client: clientset, mClient: metricsClient}
+	if err := r.initScale(config); err != nil {
+		return nil, err
+	}
+	if cacheCfg.Enabled {
+		if r.cache, err = newInformerCache(ctx, clientset, cacheCfg); err != nil {
+			return nil, err
+		}
 	}
-	return &Repository{client: clientset, mClient: metricsClient}, nil
+	return r, nil
 }
 
-func (r *Repository) ListPodsByDeployment(ctx context.Context, namespace string, deploymentName string) ([]*entity.Pod, error) {
-	dpClient := r.client.AppsV1().Deployments(namespace)
-	deployment, err := dpClient.Get(ctx, deploymentName, metav1.GetOptions{})
+// getPod reads the pod from the cache when its namespace is watched. Pods
+// from the cache are shared and must not be modified.
+func (r *Repository) getPod(ctx context.Context, namespace, podName string) (*v1.Pod, entity.Freshness, error) {
+	var pod *v1.Pod
+	var err error
+	freshness := liveFreshness()
+	if nc := r.cache.lookup(namespace); nc != nil {
+		freshness = nc.freshness()
+		pod, err = nc.pods.Pods(namespace).Get(podName)
+	} else {
+		pod, err = r.client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods of deployment: %w", err)
+		if kerrors.IsNotFound(err) {
+			return nil, freshness, service.ErrPodNotFound
+		}
+		return nil, freshness, fmt.Errorf("failed to get pod: %w", err)
 	}
+	return pod, freshness, nil
+}
 
-	selector := deployment.Spec.Selector
+// getDeployment reads the deployment from the cache when its namespace is
+// watched. Deployments from the cache are shared and must not be modified.
+func (r *Repository) getDeployment(ctx context.Context, namespace, deploymentName string) (*appsv1.Deployment, entity.Freshness, error) {
+	var deployment *appsv1.Deployment
+	var err error
+	freshness := liveFreshness()
+	if nc := r.cache.lookup(namespace); nc != nil {
+		freshness = nc.freshness()
+		deployment, err = nc.deployments.Deployments(namespace).Get(deploymentName)
+	} else {
+		deployment, err = r.client.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	}
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, freshness, service.ErrDeploymentNotFound
+		}
+		return nil, freshness, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	return deployment, freshness, nil
+}
 
-	// Convert selector to a string
-	labelSelector := metav1.FormatLabelSelector(selector)
+func (r *Repository) ListPodsByDeployment(ctx context.Context, namespace string, deploymentName string, withMetrics bool) ([]*entity.Pod, error) {
+	deployment, _, err := r.getDeployment(ctx, namespace, deploymentName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of deployment: %w", err)
+	}
 
-	pods, err := r.client.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to list pods of deployment: %w", err)
+	}
+
+	var pods []*v1.Pod
+	var replicaSets []*appsv1.ReplicaSet
+	var freshness entity.Freshness
+	if nc := r.cache.lookup(namespace); nc != nil {
+		freshness = nc.freshness()
+		if pods, err = nc.pods.Pods(namespace).List(selector); err != nil {
+			return nil, err
+		}
+		if replicaSets, err = nc.replicaSets.ReplicaSets(namespace).List(selector); err != nil {
+			return nil, err
+		}
+	} else {
+		freshness = liveFreshness()
+		opts := metav1.ListOptions{LabelSelector: selector.String()}
+		podList, err := r.client.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		pods = pointers(podList.Items)
+		rsList, err := r.client.AppsV1().ReplicaSets(namespace).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		replicaSets = pointers(rsList.Items)
+	}
+	sortObjects(pods, "")
+
+	revisions := make(map[string]string, len(replicaSets))
+	for _, rs := range replicaSets {
+		revisions[rs.Name] = rs.Annotations[revisionAnnotation]
 	}
-	var ePods []*entity.Pod
-	for _, pod := range pods.Items {
-		ePods = append(ePods, entity.NewPod(pod.Name, string(pod.Status.Phase), 0, 0, nil))
+
+	var usage map[string]map[string]v1.ResourceList
+	if withMetrics {
+		if usage, err = r.podUsage(ctx, namespace, selector.String()); err != nil {
+			log.WithContext(ctx).Errorf("failed to get pod metrics: %v", err)
+		}
+	}
+
+	ePods := make([]*entity.Pod, 0, len(pods))
+	for _, pod := range pods {
+		ePod := r.newPod(pod)
+		ePod.Owner = deployment.Name
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			ePod.Revision = revisions[owner.Name]
+		}
+		ePod.Containers = podContainers(pod, usage[pod.Name])
+		ePod.Freshness = freshness
+		ePods = append(ePods, ePod)
 	}
 	return ePods, nil
 }
 
-func (r *Repository) Scale(ctx context.Context, namespace, deploymentName string, replicas int32) error {
-	dpClient := r.client.AppsV1().Deployments(namespace)
-	deployment, err := dpClient.Get(ctx, deploymentName, metav1.GetOptions{})
+// podUsage fetches the container usage of the selected pods in one call,
+// keyed by pod and container name.
+func (r *Repository) podUsage(ctx context.Context, namespace, labelSelector string) (map[string]map[string]v1.ResourceList, error) {
+	list, err := r.mClient.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to scale deployment: %w", err)
+		return nil, err
 	}
-	deployment.Spec.Replicas = &replicas
-	_, err = dpClient.Update(ctx, deployment, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to scale deployment: %w", err)
+	usage := make(map[string]map[string]v1.ResourceList, len(list.Items))
+	for _, podMetrics := range list.Items {
+		containers := make(map[string]v1.ResourceList, len(podMetrics.Containers))
+		for _, c := range podMetrics.Containers {
+			containers[c.Name] = c.Usage
+		}
+		usage[podMetrics.Name] = containers
 	}
-	return nil
+	return usage, nil
 }
 
-func (r *Repository) Delete(ctx context.Context, namespace, podName string) error {
-	err := r.client.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+func (r *Repository) Delete(ctx context.Context, namespace, podName string, gracePeriod *time.Duration, dryRun bool) error {
+	err := r.client.CoreV1().Pods(namespace).Delete(ctx, podName, deleteOptions(gracePeriod, dryRun))
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			return service.ErrPodNotFound
@@ -91,36 +209,77 @@ func (r *Repository) Delete(ctx context.Context, namespace, podName string) erro
 }
 
 func (r *Repository) GetPodByName(ctx context.Context, namespace, podName string) (*entity.Pod, error) {
-	pod, err := r.client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	pod, freshness, err := r.getPod(ctx, namespace, podName)
 	if err != nil {
-		if kerrors.IsNotFound(err) {
-			return nil, service.ErrPodNotFound
-		} else {
-			return nil, fmt.Errorf("failed to get pod: %w", err)
-		}
+		return nil, err
 	}
+	ePod := r.newPod(pod)
+	ePod.Freshness = freshness
+	return ePod, nil
+}
 
+// newPod converts the pod without its containers.
+func (r *Repository) newPod(pod *v1.Pod) *entity.Pod {
 	totalRestarts := int32(0)
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		totalRestarts += containerStatus.RestartCount
 	}
 
-	return entity.NewPod(
+	ePod := entity.NewPod(
 		pod.Name,
 		string(pod.Status.Phase),
 		int(totalRestarts),
 		time.Since(pod.CreationTimestamp.Time),
-		nil), nil
+		nil)
+	ePod.Namespace = pod.Namespace
+	ePod.UID = string(pod.UID)
+	ePod.CreatedAt = pod.CreationTimestamp.Time
+	ePod.Labels = pod.Labels
+	ePod.Owner = r.podOwner(pod)
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		ePod.OwnerKind = owner.Kind
+		ePod.ControllerUID = string(owner.UID)
+	}
+	_, ePod.Mirror = pod.Annotations[v1.MirrorPodAnnotationKey]
+	ePod.Node = pod.Spec.NodeName
+	ePod.IP = pod.Status.PodIP
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			ePod.Ready = condition.Status == v1.ConditionTrue
+		}
+	}
+	return ePod
+}
+
+// podOwner returns the name of the workload controlling the pod. Pods of a
+// deployment are owned by a ReplicaSet, whose owner is looked up in the cache.
+// Without the cache the deployment name is recovered from the ReplicaSet name,
+// "<deployment>-<pod-template-hash>", without an extra API call.
+func (r *Repository) podOwner(pod *v1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return ""
+	}
+	if owner.Kind != "ReplicaSet" {
+		return owner.Name
+	}
+	if nc := r.cache.lookup(pod.Namespace); nc != nil {
+		if rs, err := nc.replicaSets.ReplicaSets(pod.Namespace).Get(owner.Name); err == nil {
+			if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil {
+				return rsOwner.Name
+			}
+		}
+	}
+	if hash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+		return strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+	return owner.Name
 }
 
 func (r *Repository) GetPodContainers(ctx context.Context, namespace, podName string) ([]*entity.Container, error) {
-	pod, err := r.client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	pod, _, err := r.getPod(ctx, namespace, podName)
 	if err != nil {
-		if kerrors.IsNotFound(err) {
-			return nil, service.ErrPodNotFound
-		} else {
-			return nil, fmt.Errorf("failed to get pod: %w", err)
-		}
+		return nil, err
 	}
 
 	podMetrics, err := r.mClient.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
@@ -133,6 +292,12 @@ func (r *Repository) GetPodContainers(ctx context.Context, namespace, podName st
 		metricsMap[c.Name] = c.Usage
 	}
 
+	return podContainers(pod, metricsMap), nil
+}
+
+// podContainers converts the containers of the pod; metricsMap maps container
+// names to their resource usage, which is zero for missing containers.
+func podContainers(pod *v1.Pod, metricsMap map[string]v1.ResourceList) []*entity.Container {
 	containers := make(map[string]*entity.Container, len(pod.Spec.Containers))
 	for _, container := range pod.Spec.Containers {
 		usage, ok := metricsMap[container.Name]
@@ -174,34 +339,34 @@ func (r *Repository) GetPodContainers(ctx context.Context, namespace, podName st
 			eContainer.State = "Running"
 		} else if container.State.Waiting != nil {
 			eContainer.State = "Waiting"
+			eContainer.Reason = container.State.Waiting.Reason
 		} else if container.State.Terminated != nil {
 			eContainer.State = "Terminated"
+			eContainer.Reason = container.State.Terminated.Reason
 		}
+		eContainer.Ready = container.Ready
+		eContainer.Restarts = int(container.RestartCount)
 
 		eContainers = append(eContainers, eContainer)
 
 	}
 
-	return eContainers, nil
+	return eContainers
 }
 
 func (r *Repository) GetDeploymentByName(ctx context.Context, namespace string, deploymentName string) (*entity.Deployment, error) {
-	dpClient := r.client.AppsV1().Deployments(namespace)
-	deployment, err := dpClient.Get(ctx, deploymentName, metav1.GetOptions{})
+	deployment, freshness, err := r.getDeployment(ctx, namespace, deploymentName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get deployment: %w", err)
+		return nil, err
 	}
-	return &entity.Deployment{Name: deployment.Name, Replicas: *deployment.Spec.Replicas}, nil
+	eDeployment := newDeployment(deployment)
+	eDeployment.Freshness = freshness
+	return eDeployment, nil
 }
 
 func (r *Repository) GetPodLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error) {
-	_, err := r.client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
-	if err != nil {
-		if kerrors.IsNotFound(err) {
-			return "", service.ErrPodNotFound
-		} else {
-			return "", fmt.Errorf("failed to get logs: %w", err)
-		}
+	if _, _, err := r.getPod(ctx, namespace, podName); err != nil {
+		return "", fmt.Errorf("failed to get logs: %w", err)
 	}
 
 	podLogOpts := v1.PodLogOptions{
@@ -226,85 +391,163 @@ func (r *Repository) GetPodLogs(ctx context.Context, namespace, podName, contain
 }
 
 func (r *Repository) DescribePod(ctx context.Context, namespace, podName string) (string, error) {
-	pod, err := r.client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	pod, _, err := r.getPod(ctx, namespace, podName)
 	if err != nil {
-		if kerrors.IsNotFound(err) {
-			return "", service.ErrPodNotFound
-		}
-		return "", fmt.Errorf("failed to get pod: %w", err)
+		return "", err
 	}
 
+	pod = pod.DeepCopy()
 	pod.ManagedFields = nil
 	y, err := yaml.Marshal(pod)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal pod to yaml: %w", err)
 	}
 
-	return string(y), nil
+	return string(y) + r.describeEvents(ctx, namespace, "Pod", podName), nil
 }
 
 func (r *Repository) DescribeDeployment(ctx context.Context, namespace, deploymentName string) (string, error) {
-	deployment, err := r.client.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	deployment, _, err := r.getDeployment(ctx, namespace, deploymentName)
 	if err != nil {
-		if kerrors.IsNotFound(err) {
-			return "", service.ErrDeploymentNotFound
-		}
-		return "", fmt.Errorf("failed to get deployment: %w", err)
+		return "", err
 	}
 
+	deployment = deployment.DeepCopy()
 	deployment.ManagedFields = nil
 	y, err := yaml.Marshal(deployment)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal deployment to yaml: %w", err)
 	}
 
-	return string(y), nil
+	return string(y) + r.describeEvents(ctx, namespace, "Deployment", deploymentName), nil
 }
 
-func (r *Repository) Rollback(ctx context.Context, namespace, deploymentName string) error {
+type eventSummary struct {
+	Type     string    `yaml:"type"`
+	Reason   string    `yaml:"reason"`
+	Message  string    `yaml:"message"`
+	Count    int32     `yaml:"count"`
+	LastSeen time.Time `yaml:"lastSeen"`
+}
+
+// describeEvents renders the events of an object as a YAML "events" key to
+// append to its description, like kubectl describe does. Events only add
+// context, so failing to read them is logged and the section omitted.
+func (r *Repository) describeEvents(ctx context.Context, namespace, kind, name string) string {
+	var events []*v1.Event
+	if nc := r.cache.lookup(namespace); nc != nil {
+		all, err := nc.events.Events(namespace).List(labels.Everything())
+		if err != nil {
+			log.WithContext(ctx).Warnf("failed to list events of %s %s: %v", kind, name, err)
+			return ""
+		}
+		for _, event := range all {
+			if event.InvolvedObject.Kind == kind && event.InvolvedObject.Name == name {
+				events = append(events, event)
+			}
+		}
+	} else {
+		list, err := r.client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.String(),
+		})
+		if err != nil {
+			log.WithContext(ctx).Warnf("failed to list events of %s %s: %v", kind, name, err)
+			return ""
+		}
+		for i := range list.Items {
+			events = append(events, &list.Items[i])
+		}
+	}
+	if len(events) == 0 {
+		return ""
+	}
+
+	summaries := make([]eventSummary, 0, len(events))
+	for _, event := range events {
+		lastSeen := event.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = event.EventTime.Time
+		}
+		summaries = append(summaries, eventSummary{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: lastSeen,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].LastSeen.Before(summaries[j].LastSeen) })
+	y, err := yaml.Marshal(map[string][]eventSummary{"events": summaries})
+	if err != nil {
+		log.WithContext(ctx).Warnf("failed to marshal events of %s %s: %v", kind, name, err)
+		return ""
+	}
+	return string(y)
+}
+
+func (r *Repository) Rollback(ctx context.Context, namespace, deploymentName string, dryRun bool) ([]*entity.Change, error) {
+	var changes []*entity.Change
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		changes, err = r.rollback(ctx, namespace, deploymentName, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *Repository) rollback(ctx context.Context, namespace, deploymentName string, dryRun bool) ([]*entity.Change, error) {
 	dpClient := r.client.AppsV1().Deployments(namespace)
 	deployment, err := dpClient.Get(ctx, deploymentName, metav1.GetOptions{})
 	if err != nil {
 		if kerrors.IsNotFound(err) {
-			return service.ErrDeploymentNotFound
+			return nil, service.ErrDeploymentNotFound
 		}
-		return fmt.Errorf("failed to get deployment: %w", err)
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
 	}
 
-	revisionList, err := r.client.AppsV1().ReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{
+	revisionList, err := r.client.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: "app=" + deployment.Spec.Selector.MatchLabels["app"], // Adjust label selector as needed
 	})
 	if err != nil {
-		return fmt.Errorf("failed to list replica sets: %v", err)
+		return nil, fmt.Errorf("failed to list replica sets: %w", err)
 	}
-	log.Infof("found revisions %v", revisionList)
+	log.WithContext(ctx).Debugf("found %d revisions of deployment %s", len(revisionList.Items), deploymentName)
 
 	// Find the second last revision (previous revision)
-	var previousRevision *appsv1.ReplicaSet
+	var previousRevision, currentRevision *appsv1.ReplicaSet
 	if len(revisionList.Items) > 1 {
 		// Sort ReplicaSets by creation timestamp
 		sortReplicaSetsByCreationTimestamp(revisionList.Items)
 		previousRevision = &revisionList.Items[len(revisionList.Items)-2] // Get the second last
+		currentRevision = &revisionList.Items[len(revisionList.Items)-1]
 	} else {
-		return service.ErrNoPreviousRevisionsFound
+		return nil, service.ErrNoPreviousRevisionsFound
 	}
 
 	// Get the desired ReplicaSet's template
 	if previousRevision == nil {
-		return service.ErrNoPreviousRevisionsFound
+		return nil, service.ErrNoPreviousRevisionsFound
 	}
 	oldTemplate := previousRevision.Spec.Template
+	changes := templateChanges(currentRevision, previousRevision, deployment.Spec.Template, oldTemplate)
 
 	// Update the deployment with the old template
 	deployment.Spec.Template = oldTemplate
 
-	// Apply the updated deployment
-	_, err = r.client.AppsV1().Deployments(namespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	// Apply the updated deployment. Conflicts are returned unwrapped so that
+	// the caller can retry them.
+	_, err = r.client.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{DryRun: dryRunOption(dryRun)})
+	if kerrors.IsConflict(err) {
+		return nil, err
+	}
 	if err != nil {
-		return fmt.Errorf("failed to update deployment: %v", err)
+		return nil, fmt.Errorf("failed to update deployment: %w", err)
 	}
 
-	return nil
+	return changes, nil
 }
 
 func sortReplicaSetsByCreationTimestamp(replicaSets []appsv1.ReplicaSet) {
@@ -312,3 +555,60 @@ func sortReplicaSetsByCreationTimestamp(replicaSets []appsv1.ReplicaSet) {
 		return replicaSets[i].CreationTimestamp.Before(&replicaSets[j].CreationTimestamp)
 	})
 }
+
+// templateChanges lists the revision and per-container image changes caused
+// by replacing the deployment's current pod template with the target one.
+func templateChanges(current, target *appsv1.ReplicaSet, from, to v1.PodTemplateSpec) []*entity.Change {
+	changes := []*entity.Change{
+		entity.NewChange("revision", current.Annotations[revisionAnnotation], target.Annotations[revisionAnnotation]),
+	}
+
+	images := make(map[string]string, len(from.Spec.Containers))
+	for _, c := range from.Spec.Containers {
+		images[c.Name] = c.Image
+	}
+	for _, c := range to.Spec.Containers {
+		if images[c.Name] != c.Image {
+			changes = append(changes, entity.NewChange(
+				fmt.Sprintf("template.spec.containers[%s].image", c.Name), images[c.Name], c.Image))
+		}
+	}
+	return changes
+}
+
+func deleteOptions(gracePeriod *time.Duration, dryRun bool) metav1.DeleteOptions {
+	opts := metav1.DeleteOptions{DryRun: dryRunOption(dryRun)}
+	if gracePeriod != nil {
+		seconds := int64(gracePeriod.Seconds())
+		opts.GracePeriodSeconds = &seconds
+	}
+	return opts
+}
+
+func dryRunOption(dryRun bool) []string {
+	if dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// ServerVersion requests the version through the discovery REST client, as
+// the discovery methods do, but bound by ctx.
+func (r *Repository) ServerVersion(ctx context.Context) (string, error) {
+	body, err := r.client.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("failed to parse server version: %w", err)
+	}
+	return info.GitVersion, nil
+}
+
+// CheckMetricsAPI reports whether the metrics API serving container usage is
+// registered with the API server.
+func (r *Repository) CheckMetricsAPI(ctx context.Context) error {
+	gv := metricsv1beta1.SchemeGroupVersion
+	return r.client.Discovery().RESTClient().Get().AbsPath("/apis", gv.Group, gv.Version).Do(ctx).Error()
+}
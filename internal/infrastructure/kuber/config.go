@@ -0,0 +1,139 @@
+package kuber
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// DefaultClusterName names the cluster when no clusters are listed.
+const DefaultClusterName = "default"
+
+type Config struct {
+	ClusterConfig `yaml:",inline"`
+	// Clusters serves several clusters, usually contexts of one kubeconfig.
+	// Clusters without a kubeconfig use the one above. When it is empty the
+	// settings above make up the only cluster, named "default".
+	Clusters map[string]ClusterConfig `yaml:"clusters,omitempty"`
+	// DefaultCluster is served by the routes without a cluster prefix.
+	DefaultCluster string `yaml:"defaultCluster,omitempty"`
+	// Cache applies to every cluster.
+	Cache CacheConfig `yaml:"cache"`
+}
+
+type ClusterConfig struct {
+	// Kubeconfig is the path to the kubeconfig file. When it is empty the
+	// KUBECONFIG environment and ~/.kube/config are tried, then the in-cluster
+	// service account.
+	Kubeconfig string `yaml:"kubeconfig,omitempty"`
+	// Context selects a kubeconfig context instead of the current one.
+	Context string `yaml:"context,omitempty"`
+	// Namespace overrides the default namespace of the context or of the
+	// service account.
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// RESTConfig resolves the client configuration and the default namespace.
+func (c ClusterConfig) RESTConfig() (*rest.Config, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = c.Kubeconfig
+	if c.Kubeconfig == "" && os.Getenv(clientcmd.RecommendedConfigPathEnvVar) == "" && !fileExists(clientcmd.RecommendedHomeFile) {
+		return c.inClusterConfig()
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{
+		CurrentContext: c.Context,
+	})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	namespace := c.Namespace
+	if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, "", fmt.Errorf("failed to resolve namespace: %w", err)
+		}
+	}
+	return config, namespace, nil
+}
+
+func (c ClusterConfig) inClusterConfig() (*rest.Config, string, error) {
+	if c.Context != "" {
+		return nil, "", errors.New("kubernetes context is set but no kubeconfig was found")
+	}
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("no kubeconfig found and not running in a cluster: %w", err)
+	}
+	namespace := c.Namespace
+	if namespace == "" {
+		data, err := os.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read service account namespace: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	return config, namespace, nil
+}
+
+// ClusterConfigs returns the settings of every cluster by name and the name
+// of the default cluster.
+func (c Config) ClusterConfigs() (map[string]ClusterConfig, string) {
+	if len(c.Clusters) == 0 {
+		return map[string]ClusterConfig{DefaultClusterName: c.ClusterConfig}, DefaultClusterName
+	}
+	clusters := make(map[string]ClusterConfig, len(c.Clusters))
+	for name, cluster := range c.Clusters {
+		if cluster.Kubeconfig == "" {
+			cluster.Kubeconfig = c.Kubeconfig
+		}
+		clusters[name] = cluster
+	}
+	defaultCluster := c.DefaultCluster
+	if defaultCluster == "" && len(clusters) == 1 {
+		for name := range clusters {
+			defaultCluster = name
+		}
+	}
+	return clusters, defaultCluster
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	for name := range c.Clusters {
+		if msgs := validation.IsDNS1123Label(name); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("kubernetes.clusters: bad cluster name %q: %s", name, strings.Join(msgs, ", ")))
+		}
+	}
+	if len(c.Clusters) == 0 {
+		if c.DefaultCluster != "" && c.DefaultCluster != DefaultClusterName {
+			errs = append(errs, fmt.Errorf("kubernetes.defaultCluster: unknown cluster %q", c.DefaultCluster))
+		}
+	} else if _, defaultCluster := c.ClusterConfigs(); defaultCluster == "" {
+		errs = append(errs, errors.New("kubernetes.defaultCluster is required with several clusters"))
+	} else if _, ok := c.Clusters[defaultCluster]; !ok {
+		errs = append(errs, fmt.Errorf("kubernetes.defaultCluster: unknown cluster %q", defaultCluster))
+	}
+	if c.Cache.Resync < 0 {
+		errs = append(errs, errors.New("kubernetes.cache.resync must not be negative"))
+	}
+	for _, namespace := range c.Cache.Namespaces {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("kubernetes.cache.namespaces: bad namespace %q: %s", namespace, strings.Join(msgs, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
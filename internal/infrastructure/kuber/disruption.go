@@ -0,0 +1,60 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func newDisruptionBudget(pdb *policyv1.PodDisruptionBudget) *entity.DisruptionBudget {
+	budget := &entity.DisruptionBudget{
+		Namespace:          pdb.Namespace,
+		Name:               pdb.Name,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		ExpectedPods:       pdb.Status.ExpectedPods,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+	}
+	if pdb.Spec.MinAvailable != nil {
+		budget.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		budget.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	return budget
+}
+
+func (r *Repository) ListDisruptionBudgets(ctx context.Context, namespace string, podLabels map[string]string, live bool) ([]*entity.DisruptionBudget, error) {
+	var pdbs []*policyv1.PodDisruptionBudget
+	if nc := r.cache.lookup(namespace); nc != nil && !live {
+		var err error
+		if pdbs, err = nc.budgets.PodDisruptionBudgets(namespace).List(labels.Everything()); err != nil {
+			return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+		}
+	} else {
+		list, err := r.client.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pod disruption budgets: %w", err)
+		}
+		pdbs = pointers(list.Items)
+	}
+	var budgets []*entity.DisruptionBudget
+	for _, pdb := range pdbs {
+		// A nil selector selects no pods, an empty one all pods.
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			budgets = append(budgets, newDisruptionBudget(pdb))
+		}
+	}
+	return budgets, nil
+}
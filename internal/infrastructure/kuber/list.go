@@ -0,0 +1,227 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func listOptions(opts entity.ListOptions) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+		Limit:         opts.Limit,
+		Continue:      opts.Continue,
+	}
+}
+
+// cachedList returns the cache of the namespace when it can serve the list.
+// Listers only filter by labels and do not paginate.
+func (r *Repository) cachedList(namespace string, opts entity.ListOptions) (*namespaceCache, labels.Selector, error) {
+	nc := r.cache.lookup(namespace)
+	if nc == nil || opts.FieldSelector != "" || opts.Limit != 0 || opts.Continue != "" {
+		return nil, nil, nil
+	}
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, nil, &service.InvalidArgumentError{Argument: "labelSelector", Reason: err.Error()}
+	}
+	return nc, selector, nil
+}
+
+// sortObjects orders objects by name unless sortBy asks for another order.
+func sortObjects[T metav1.Object](objects []T, sortBy string) {
+	descending := strings.HasPrefix(sortBy, "-")
+	less := func(a, b T) bool { return a.GetName() < b.GetName() }
+	if strings.TrimPrefix(sortBy, "-") == "age" {
+		// The youngest object, created last, comes first.
+		less = func(a, b T) bool {
+			return a.GetCreationTimestamp().After(b.GetCreationTimestamp().Time)
+		}
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if descending {
+			return less(objects[j], objects[i])
+		}
+		return less(objects[i], objects[j])
+	})
+}
+
+// pointers returns pointers to the items of a list response.
+func pointers[T any](items []T) []*T {
+	res := make([]*T, 0, len(items))
+	for i := range items {
+		res = append(res, &items[i])
+	}
+	return res
+}
+
+func age(object metav1.Object) time.Duration {
+	return time.Since(object.GetCreationTimestamp().Time)
+}
+
+func (r *Repository) ListNamespaces(ctx context.Context, opts entity.ListOptions) (*entity.List[*entity.Namespace], error) {
+	list, err := r.client.CoreV1().Namespaces().List(ctx, listOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	namespaces := pointers(list.Items)
+	sortObjects(namespaces, opts.SortBy)
+
+	res := &entity.List[*entity.Namespace]{Continue: list.Continue, Freshness: liveFreshness()}
+	for _, namespace := range namespaces {
+		res.Items = append(res.Items, &entity.Namespace{
+			Name:   namespace.Name,
+			Status: string(namespace.Status.Phase),
+			Age:    age(namespace),
+			Labels: namespace.Labels,
+		})
+	}
+	return res, nil
+}
+
+func (r *Repository) ListNodes(ctx context.Context, opts entity.ListOptions) (*entity.List[*entity.Node], error) {
+	list, err := r.client.CoreV1().Nodes().List(ctx, listOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodes := pointers(list.Items)
+	sortObjects(nodes, opts.SortBy)
+
+	res := &entity.List[*entity.Node]{Continue: list.Continue, Freshness: liveFreshness()}
+	for _, node := range nodes {
+		res.Items = append(res.Items, newNode(node))
+	}
+	return res, nil
+}
+
+func (r *Repository) ListPods(ctx context.Context, namespace string, opts entity.ListOptions) (*entity.List[*entity.Pod], error) {
+	nc, selector, err := r.cachedList(namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []*v1.Pod
+	res := &entity.List[*entity.Pod]{}
+	if nc != nil {
+		res.Freshness = nc.freshness()
+		if pods, err = nc.pods.Pods(namespace).List(selector); err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+	} else {
+		list, err := r.client.CoreV1().Pods(namespace).List(ctx, listOptions(opts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		res.Continue = list.Continue
+		res.Freshness = liveFreshness()
+		pods = pointers(list.Items)
+	}
+	sortObjects(pods, opts.SortBy)
+
+	for _, pod := range pods {
+		ePod := r.newPod(pod)
+		ePod.Containers = podContainers(pod, nil)
+		ePod.Freshness = res.Freshness
+		res.Items = append(res.Items, ePod)
+	}
+	return res, nil
+}
+
+func (r *Repository) ListDeployments(ctx context.Context, namespace string, opts entity.ListOptions) (*entity.List[*entity.Deployment], error) {
+	nc, selector, err := r.cachedList(namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var deployments []*appsv1.Deployment
+	res := &entity.List[*entity.Deployment]{}
+	if nc != nil {
+		res.Freshness = nc.freshness()
+		if deployments, err = nc.deployments.Deployments(namespace).List(selector); err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %w", err)
+		}
+	} else {
+		list, err := r.client.AppsV1().Deployments(namespace).List(ctx, listOptions(opts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %w", err)
+		}
+		res.Continue = list.Continue
+		res.Freshness = liveFreshness()
+		deployments = pointers(list.Items)
+	}
+	sortObjects(deployments, opts.SortBy)
+
+	for _, deployment := range deployments {
+		eDeployment := newDeployment(deployment)
+		eDeployment.Freshness = res.Freshness
+		res.Items = append(res.Items, eDeployment)
+	}
+	return res, nil
+}
+
+func (r *Repository) ListStatefulSets(ctx context.Context, namespace string, opts entity.ListOptions) (*entity.List[*entity.StatefulSet], error) {
+	list, err := r.client.AppsV1().StatefulSets(namespace).List(ctx, listOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	statefulSets := pointers(list.Items)
+	sortObjects(statefulSets, opts.SortBy)
+
+	res := &entity.List[*entity.StatefulSet]{Continue: list.Continue, Freshness: liveFreshness()}
+	for _, statefulSet := range statefulSets {
+		replicas := int32(1)
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		res.Items = append(res.Items, &entity.StatefulSet{
+			Name:            statefulSet.Name,
+			Replicas:        replicas,
+			CurrentReplicas: statefulSet.Status.Replicas,
+			ReadyReplicas:   statefulSet.Status.ReadyReplicas,
+			Age:             age(statefulSet),
+			Labels:          statefulSet.Labels,
+		})
+	}
+	return res, nil
+}
+
+func (r *Repository) ListServices(ctx context.Context, namespace string, opts entity.ListOptions) (*entity.List[*entity.Service], error) {
+	list, err := r.client.CoreV1().Services(namespace).List(ctx, listOptions(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	services := pointers(list.Items)
+	sortObjects(services, opts.SortBy)
+
+	res := &entity.List[*entity.Service]{Continue: list.Continue, Freshness: liveFreshness()}
+	for _, svc := range services {
+		ports := make([]entity.ServicePort, 0, len(svc.Spec.Ports))
+		for _, port := range svc.Spec.Ports {
+			ports = append(ports, entity.ServicePort{
+				Name:       port.Name,
+				Protocol:   string(port.Protocol),
+				Port:       port.Port,
+				TargetPort: port.TargetPort.String(),
+			})
+		}
+		res.Items = append(res.Items, &entity.Service{
+			Name:      svc.Name,
+			Type:      string(svc.Spec.Type),
+			ClusterIP: svc.Spec.ClusterIP,
+			Ports:     ports,
+			Age:       age(svc),
+			Labels:    svc.Labels,
+		})
+	}
+	return res, nil
+}
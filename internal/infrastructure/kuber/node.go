@@ -0,0 +1,133 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/util/retry"
+)
+
+// nodeRolePrefix prefixes the labels naming the roles of a node.
+const nodeRolePrefix = "node-role.kubernetes.io/"
+
+func newNode(node *v1.Node) *entity.Node {
+	eNode := &entity.Node{
+		Name:           node.Name,
+		Unschedulable:  node.Spec.Unschedulable,
+		KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+		Age:            age(node),
+		Labels:         node.Labels,
+		Capacity:       resourceQuantities(node.Status.Capacity),
+		Allocatable:    resourceQuantities(node.Status.Allocatable),
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			eNode.Ready = condition.Status == v1.ConditionTrue
+		}
+		eNode.Conditions = append(eNode.Conditions, &entity.NodeCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.Time,
+		})
+	}
+	for label := range node.Labels {
+		if role, ok := strings.CutPrefix(label, nodeRolePrefix); ok && role != "" {
+			eNode.Roles = append(eNode.Roles, role)
+		}
+	}
+	sort.Strings(eNode.Roles)
+	return eNode
+}
+
+func resourceQuantities(resources v1.ResourceList) map[string]string {
+	res := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		res[string(name)] = quantity.String()
+	}
+	return res
+}
+
+func (r *Repository) GetNode(ctx context.Context, name string) (*entity.Node, error) {
+	node, err := r.client.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, service.ErrNodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	return newNode(node), nil
+}
+
+func (r *Repository) SetUnschedulable(ctx context.Context, nodeName string, unschedulable, dryRun bool) error {
+	nodes := r.client.CoreV1().Nodes()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := nodes.Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if node.Spec.Unschedulable == unschedulable {
+			return nil
+		}
+		node.Spec.Unschedulable = unschedulable
+		_, err = nodes.Update(ctx, node, metav1.UpdateOptions{DryRun: dryRunOption(dryRun)})
+		return err
+	})
+	if kerrors.IsNotFound(err) {
+		return service.ErrNodeNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update node: %w", err)
+	}
+	return nil
+}
+
+// ListPodsOnNode lists the pods of every namespace bound to the node.
+func (r *Repository) ListPodsOnNode(ctx context.Context, nodeName string) ([]*entity.Pod, error) {
+	list, err := r.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of node: %w", err)
+	}
+	pods := make([]*entity.Pod, 0, len(list.Items))
+	for i := range list.Items {
+		pods = append(pods, r.newPod(&list.Items[i]))
+	}
+	return pods, nil
+}
+
+func (r *Repository) Evict(ctx context.Context, namespace, podName string, gracePeriod *time.Duration, dryRun bool) error {
+	opts := deleteOptions(gracePeriod, dryRun)
+	err := r.client.PolicyV1().Evictions(namespace).Evict(ctx, &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: namespace, Name: podName},
+		DeleteOptions: &opts,
+	})
+	switch {
+	case err == nil:
+		return nil
+	case kerrors.IsNotFound(err):
+		return service.ErrPodNotFound
+	case kerrors.IsTooManyRequests(err):
+		// The API server refuses evictions violating a disruption budget
+		// with 429, the budget in the message and when to retry.
+		blocked := &service.EvictionBlockedError{Namespace: namespace, Pod: podName, Reason: err.Error()}
+		if seconds, ok := kerrors.SuggestsClientDelay(err); ok {
+			blocked.RetryAfter = time.Duration(seconds) * time.Second
+		}
+		return blocked
+	default:
+		return fmt.Errorf("failed to evict pod: %w", err)
+	}
+}
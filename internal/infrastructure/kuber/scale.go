@@ -0,0 +1,134 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/util/retry"
+)
+
+func (r *Repository) initScale(config *rest.Config) error {
+	// Discovery is cached and refreshed when a kind is not found, so custom
+	// resources installed later are picked up.
+	discovery := memory.NewMemCacheClient(r.client.Discovery())
+	r.mapper = restmapper.NewDeferredDiscoveryRESTMapper(discovery)
+	r.scaleKinds = scale.NewDiscoveryScaleKindResolver(discovery)
+	scales, err := scale.NewForConfig(config, r.mapper, dynamic.LegacyAPIPathResolverFunc, r.scaleKinds)
+	if err != nil {
+		return fmt.Errorf("failed to create scale client: %w", err)
+	}
+	r.scales = scales
+	r.metadata, err = metadata.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata client: %w", err)
+	}
+	return nil
+}
+
+// resourceFor resolves the workload to its API resource. Its kind may also
+// be given as a resource name, e.g. statefulsets, and the returned workload
+// has the canonical group and kind.
+func (r *Repository) resourceFor(workload entity.Workload) (schema.GroupVersionResource, entity.Workload, error) {
+	mapping, err := r.mapper.RESTMapping(schema.GroupKind{Group: workload.Group, Kind: workload.Kind})
+	if meta.IsNoMatchError(err) {
+		gvk, kindErr := r.mapper.KindFor(schema.GroupVersionResource{Group: workload.Group, Resource: strings.ToLower(workload.Kind)})
+		if kindErr == nil {
+			mapping, err = r.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if meta.IsNoMatchError(err) {
+		return schema.GroupVersionResource{}, workload, &service.InvalidArgumentError{
+			Argument: "kind",
+			Reason:   fmt.Sprintf("no kind %s in API group %q", workload.Kind, workload.Group),
+		}
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, workload, fmt.Errorf("failed to resolve kind: %w", err)
+	}
+	if _, err := r.scaleKinds.ScaleForResource(mapping.Resource); err != nil {
+		return schema.GroupVersionResource{}, workload, &service.NotScalableError{Kind: workload.Kind}
+	}
+	// Kinds are matched regardless of case; the resource has the canonical one.
+	gvk, err := r.mapper.KindFor(mapping.Resource)
+	if err != nil {
+		return schema.GroupVersionResource{}, workload, fmt.Errorf("failed to resolve kind: %w", err)
+	}
+	workload.Group, workload.Kind = gvk.Group, gvk.Kind
+	return mapping.Resource, workload, nil
+}
+
+// workloadNotFound keeps reporting missing deployments as such.
+func workloadNotFound(workload entity.Workload) error {
+	if workload.IsDeployment() {
+		return service.ErrDeploymentNotFound
+	}
+	return service.ErrWorkloadNotFound
+}
+
+func (r *Repository) GetScale(ctx context.Context, namespace string, workload entity.Workload) (*entity.Scale, error) {
+	resource, workload, err := r.resourceFor(workload)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := r.scales.Scales(namespace).Get(ctx, resource.GroupResource(), workload.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, workloadNotFound(workload)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale: %w", err)
+	}
+	// The scale subresource does not carry the labels of its object.
+	object, err := r.metadata.Resource(resource).Namespace(namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, workloadNotFound(workload)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", workload, err)
+	}
+	return &entity.Scale{
+		Workload:        workload,
+		Labels:          object.Labels,
+		Replicas:        sc.Spec.Replicas,
+		CurrentReplicas: sc.Status.Replicas,
+		Selector:        sc.Status.Selector,
+	}, nil
+}
+
+func (r *Repository) Scale(ctx context.Context, namespace string, workload entity.Workload, replicas int32, dryRun bool) error {
+	resource, workload, err := r.resourceFor(workload)
+	if err != nil {
+		return err
+	}
+	scales := r.scales.Scales(namespace)
+	// Re-read the scale and re-apply the change when another writer updated
+	// the workload between our Get and Update.
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sc, err := scales.Get(ctx, resource.GroupResource(), workload.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		sc.Spec.Replicas = replicas
+		_, err = scales.Update(ctx, resource.GroupResource(), sc, metav1.UpdateOptions{DryRun: dryRunOption(dryRun)})
+		return err
+	})
+	if kerrors.IsNotFound(err) {
+		return workloadNotFound(workload)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to scale %s: %w", workload, err)
+	}
+	return nil
+}
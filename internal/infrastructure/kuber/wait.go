@@ -0,0 +1,206 @@
+package kuber
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// WaitForPodDeletion watches the pod from its current resource version, so a
+// deletion between the read and the watch is not missed.
+func (r *Repository) WaitForPodDeletion(ctx context.Context, namespace, podName, uid string) error {
+	podClient := r.client.CoreV1().Pods(namespace)
+	pod, err := podClient.Get(ctx, podName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pod: %w", err)
+	}
+	if string(pod.UID) != uid {
+		// A StatefulSet already recreated the pod under the same name.
+		return nil
+	}
+
+	w, err := podClient.Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", podName).String(),
+		ResourceVersion: pod.ResourceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch pod: %w", err)
+	}
+	_, err = watchtools.UntilWithoutRetry(ctx, w, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Error {
+			return false, kerrors.FromObject(event.Object)
+		}
+		return event.Type == watch.Deleted, nil
+	})
+	return err
+}
+
+// WaitForReplicas watches the deployment until its controller reports the
+// requested number of ready replicas.
+func (r *Repository) WaitForReplicas(ctx context.Context, namespace, deploymentName string, replicas int32) error {
+	dpClient := r.client.AppsV1().Deployments(namespace)
+	deployment, err := dpClient.Get(ctx, deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if newDeployment(deployment).ScaledTo(replicas) {
+		return nil
+	}
+
+	w, err := dpClient.Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", deploymentName).String(),
+		ResourceVersion: deployment.ResourceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch deployment: %w", err)
+	}
+	_, err = watchtools.UntilWithoutRetry(ctx, w, func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Error:
+			return false, kerrors.FromObject(event.Object)
+		case watch.Deleted:
+			return false, fmt.Errorf("deployment %s was deleted", deploymentName)
+		}
+		deployment, ok := event.Object.(*appsv1.Deployment)
+		return ok && newDeployment(deployment).ScaledTo(replicas), nil
+	})
+	return err
+}
+
+// isSibling reports whether the pod belongs to the controller of original.
+func isSibling(pod *v1.Pod, original *entity.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && string(owner.UID) == original.ControllerUID && string(pod.UID) != original.UID
+}
+
+// isCandidate reports whether the pod may replace original: it belongs to
+// the controller of original and is not going away.
+func isCandidate(pod *v1.Pod, original *entity.Pod) bool {
+	return isSibling(pod, original) && pod.DeletionTimestamp == nil
+}
+
+// replacementSelector selects the pods sharing the labels of original, which
+// the pods of its controller carry.
+func replacementSelector(original *entity.Pod) string {
+	return labels.SelectorFromSet(original.Labels).String()
+}
+
+func (r *Repository) fullPod(pod *v1.Pod) *entity.Pod {
+	ePod := r.newPod(pod)
+	ePod.Containers = podContainers(pod, nil)
+	return ePod
+}
+
+// ListSiblingPods lists the pods live, as a stale cache could miss a sibling
+// that would then be taken for a replacement.
+func (r *Repository) ListSiblingPods(ctx context.Context, original *entity.Pod) ([]string, error) {
+	list, err := r.client.CoreV1().Pods(original.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: replacementSelector(original),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var siblings []string
+	for i := range list.Items {
+		if pod := &list.Items[i]; isSibling(pod, original) {
+			siblings = append(siblings, string(pod.UID))
+		}
+	}
+	return siblings, nil
+}
+
+func (r *Repository) GetReplacementPod(ctx context.Context, original *entity.Pod, accept func(*entity.Pod) bool) (*entity.Pod, error) {
+	list, err := r.client.CoreV1().Pods(original.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: replacementSelector(original),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	var candidates []*v1.Pod
+	for i := range list.Items {
+		if pod := &list.Items[i]; isCandidate(pod, original) {
+			candidates = append(candidates, pod)
+		}
+	}
+	sortObjects(candidates, "age")
+	for _, pod := range candidates {
+		if ePod := r.fullPod(pod); accept(ePod) {
+			return ePod, nil
+		}
+	}
+	return nil, service.ErrPodNotFound
+}
+
+// WaitForReplacementPod lists the candidate pods and watches them from the
+// resource version of the list, so a replacement created in between is seen.
+func (r *Repository) WaitForReplacementPod(ctx context.Context, original *entity.Pod, accept func(*entity.Pod) bool, condition func(*entity.Pod) (bool, error)) (*entity.Pod, error) {
+	podClient := r.client.CoreV1().Pods(original.Namespace)
+	opts := metav1.ListOptions{LabelSelector: replacementSelector(original)}
+	list, err := podClient.List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range list.Items {
+		if pod := &list.Items[i]; isCandidate(pod, original) {
+			if ePod := r.fullPod(pod); accept(ePod) {
+				if done, err := condition(ePod); done || err != nil {
+					return ePod, err
+				}
+			}
+		}
+	}
+
+	opts.ResourceVersion = list.ResourceVersion
+	w, err := podClient.Watch(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch pods: %w", err)
+	}
+	var replacement *entity.Pod
+	_, err = watchtools.UntilWithoutRetry(ctx, w, func(event watch.Event) (bool, error) {
+		switch event.Type {
+		case watch.Error:
+			return false, kerrors.FromObject(event.Object)
+		case watch.Deleted:
+			return false, nil
+		}
+		pod, ok := event.Object.(*v1.Pod)
+		if !ok || !isCandidate(pod, original) {
+			return false, nil
+		}
+		ePod := r.fullPod(pod)
+		if !accept(ePod) {
+			return false, nil
+		}
+		replacement = ePod
+		return condition(replacement)
+	})
+	return replacement, err
+}
+
+func newDeployment(deployment *appsv1.Deployment) *entity.Deployment {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return &entity.Deployment{
+		Name:            deployment.Name,
+		Replicas:        replicas,
+		CurrentReplicas: deployment.Status.Replicas,
+		ReadyReplicas:   deployment.Status.ReadyReplicas,
+		Labels:          deployment.Labels,
+		PodLabels:       deployment.Spec.Template.Labels,
+	}
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the correlation ID of a request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the correlation ID, or "" outside of a request.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Setup switches the standard logger to JSON lines annotated with the
+// request correlation data found in the context of each entry.
+func Setup(level string) error {
+	lvl, err := log.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	log.SetLevel(lvl)
+	log.SetFormatter(&log.JSONFormatter{})
+	log.AddHook(contextHook{})
+	return nil
+}
+
+// contextHook copies request correlation data into entries logged with
+// log.WithContext(ctx).
+type contextHook struct{}
+
+func (contextHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (contextHook) Fire(entry *log.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if requestID := RequestIDFromContext(entry.Context); requestID != "" {
+		entry.Data["request_id"] = requestID
+	}
+	if principal := service.PrincipalFromContext(entry.Context); principal != "" {
+		entry.Data["principal"] = principal
+	}
+	if span := trace.SpanContextFromContext(entry.Context); span.IsValid() {
+		entry.Data["trace_id"] = span.TraceID().String()
+	}
+	return nil
+}
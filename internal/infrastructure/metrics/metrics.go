@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "fenrir_executor"
+
+// Metrics holds the collectors describing the executor itself: its HTTP API,
+// the actions it performs and the Kubernetes API calls it makes.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight prometheus.Gauge
+
+	actions         *prometheus.CounterVec
+	actionDuration  *prometheus.HistogramVec
+	actionsInFlight *prometheus.GaugeVec
+
+	kubeRequests *prometheus.CounterVec
+	kubeDuration *prometheus.HistogramVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status code.",
+			Buckets:   []float64{.01, .05, .1, .5, 1, 5, 15, 30, 60, 120, 300},
+		}, []string{"route", "method", "status"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+		actions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "actions_total",
+			Help:      "Executor actions by action and outcome.",
+		}, []string{"action", "outcome"}),
+		actionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "action_duration_seconds",
+			Help:      "Executor action duration by action and outcome.",
+			Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{"action", "outcome"}),
+		actionsInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "actions_in_flight",
+			Help:      "Executor actions currently running.",
+		}, []string{"action"}),
+		kubeRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "kubernetes_requests_total",
+			Help:      "Kubernetes API requests by verb, resource and status code; code is \"error\" when no response was received.",
+		}, []string{"verb", "resource", "code"}),
+		kubeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "kubernetes_request_duration_seconds",
+			Help:      "Kubernetes API request latency by verb and resource.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"verb", "resource"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+		m.actions, m.actionDuration, m.actionsInFlight,
+		m.kubeRequests, m.kubeDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) RequestStarted() {
+	m.httpInFlight.Inc()
+}
+
+func (m *Metrics) RequestFinished(route, method string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	m.httpInFlight.Dec()
+	m.httpRequests.WithLabelValues(route, method, code).Inc()
+	m.httpDuration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ActionStarted(action string) {
+	m.actionsInFlight.WithLabelValues(action).Inc()
+}
+
+func (m *Metrics) ActionFinished(action, outcome string, duration time.Duration) {
+	m.actionsInFlight.WithLabelValues(action).Dec()
+	m.actions.WithLabelValues(action, outcome).Inc()
+	m.actionDuration.WithLabelValues(action, outcome).Observe(duration.Seconds())
+}
+
+// WrapTransport instruments a Kubernetes client transport. It is meant to be
+// passed to rest.Config.Wrap.
+func (m *Metrics) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{metrics: m, next: rt}
+}
+
+type transport struct {
+	metrics *Metrics
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	verb, resource := requestVerb(req), requestResource(req.URL.Path)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.metrics.kubeRequests.WithLabelValues(verb, resource, code).Inc()
+	t.metrics.kubeDuration.WithLabelValues(verb, resource).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+func requestVerb(req *http.Request) string {
+	if req.Method == http.MethodGet && req.URL.Query().Get("watch") == "true" {
+		return "WATCH"
+	}
+	return req.Method
+}
+
+// requestResource extracts the resource (and subresource) from a Kubernetes
+// API path such as /api/v1/namespaces/default/pods/web-0/log.
+func requestResource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return "other"
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+	switch len(parts) {
+	case 0:
+		return "discovery"
+	case 1, 2:
+		return parts[0]
+	default:
+		return parts[0] + "/" + parts[2]
+	}
+}
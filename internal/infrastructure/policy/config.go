@@ -0,0 +1,196 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// File is the declarative policy document loaded from YAML.
+type File struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Rule is a single guardrail. A rule applies to an action when its match
+// section and (optional) time window both match; an applicable rule refuses
+// the action when any of its constraints is violated.
+type Rule struct {
+	Name   string  `yaml:"name"`
+	Match  Match   `yaml:"match"`
+	Window *Window `yaml:"window,omitempty"`
+
+	Deny bool `yaml:"deny,omitempty"`
+	// MinReplicas and MaxReplicas bound the replicas of scale actions and
+	// the limits set on autoscalers.
+	MinReplicas *int32     `yaml:"minReplicas,omitempty"`
+	MaxReplicas *int32     `yaml:"maxReplicas,omitempty"`
+	RateLimit   *RateLimit `yaml:"rateLimit,omitempty"`
+	// RequireApproval holds the action until a different principal approves it.
+	RequireApproval bool `yaml:"requireApproval,omitempty"`
+}
+
+type Match struct {
+	// Actions are executor action kinds (restart, rolling-restart, scale,
+	// rollback, cordon, uncordon, drain, autoscale); empty matches all.
+	Actions []string `yaml:"actions,omitempty"`
+	// Clusters and Namespaces are glob patterns as understood by path.Match;
+	// empty matches all. Node actions have an empty namespace, which only
+	// "*" matches.
+	Clusters   []string          `yaml:"clusters,omitempty"`
+	Namespaces []string          `yaml:"namespaces,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	// TargetReplicas matches scale actions by the requested replica count.
+	TargetReplicas *Range `yaml:"targetReplicas,omitempty"`
+}
+
+// Range is an inclusive interval; a missing bound is unlimited.
+type Range struct {
+	Min *int32 `yaml:"min,omitempty"`
+	Max *int32 `yaml:"max,omitempty"`
+}
+
+func (r *Range) contains(value int32) bool {
+	return (r.Min == nil || value >= *r.Min) && (r.Max == nil || value <= *r.Max)
+}
+
+// Window restricts a rule to a recurring period of time, e.g. a change freeze.
+type Window struct {
+	Days     []string `yaml:"days,omitempty"`
+	From     string   `yaml:"from,omitempty"`
+	To       string   `yaml:"to,omitempty"`
+	Timezone string   `yaml:"timezone,omitempty"`
+
+	location *time.Location
+	days     map[time.Weekday]bool
+	from, to time.Duration
+}
+
+// RateLimit allows at most Max actions per workload within the Per period.
+type RateLimit struct {
+	Max int           `yaml:"max"`
+	Per time.Duration `yaml:"per"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Load reads and validates a policy file.
+func Load(filename string) (*File, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var f File
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	if err := f.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", filename, err)
+	}
+	return &f, nil
+}
+
+func (f *File) validate() error {
+	names := make(map[string]bool, len(f.Rules))
+	for i, rule := range f.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule #%d: name is required", i+1)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("rule %q: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if !r.Deny && r.MinReplicas == nil && r.MaxReplicas == nil && r.RateLimit == nil && !r.RequireApproval {
+		return errors.New("at least one of deny, minReplicas, maxReplicas, rateLimit or requireApproval is required")
+	}
+	if r.MinReplicas != nil && r.MaxReplicas != nil && *r.MinReplicas > *r.MaxReplicas {
+		return errors.New("minReplicas is greater than maxReplicas")
+	}
+	if r.RateLimit != nil && (r.RateLimit.Max <= 0 || r.RateLimit.Per <= 0) {
+		return errors.New("rateLimit requires positive max and per")
+	}
+	for _, action := range r.Match.Actions {
+		switch action {
+		case "restart", "rolling-restart", "scale", "rollback", "cordon", "uncordon", "drain", "autoscale":
+		default:
+			return fmt.Errorf("unknown action %q", action)
+		}
+	}
+	for _, pattern := range r.Match.Clusters {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad cluster pattern %q: %w", pattern, err)
+		}
+	}
+	for _, pattern := range r.Match.Namespaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad namespace pattern %q: %w", pattern, err)
+		}
+	}
+	if r.Window != nil {
+		return r.Window.compile()
+	}
+	return nil
+}
+
+func (w *Window) compile() error {
+	w.location = time.UTC
+	if w.Timezone != "" {
+		location, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return fmt.Errorf("bad window timezone: %w", err)
+		}
+		w.location = location
+	}
+
+	w.days = make(map[time.Weekday]bool, len(w.Days))
+	for _, day := range w.Days {
+		weekday, ok := weekdays[strings.ToLower(day)[:min(3, len(day))]]
+		if !ok {
+			return fmt.Errorf("bad window day %q", day)
+		}
+		w.days[weekday] = true
+	}
+
+	var err error
+	if w.from, err = parseClock(w.From, 0); err != nil {
+		return fmt.Errorf("bad window start: %w", err)
+	}
+	if w.to, err = parseClock(w.To, 24*time.Hour); err != nil {
+		return fmt.Errorf("bad window end: %w", err)
+	}
+	return nil
+}
+
+// parseClock converts "HH:MM" into an offset from midnight.
+func parseClock(value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
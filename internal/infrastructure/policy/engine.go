@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+)
+
+// Engine evaluates executor actions against the rules of a policy file.
+type Engine struct {
+	rules []*Rule
+	now   func() time.Time
+
+	mu sync.Mutex
+	// history keeps the times of allowed actions per rate limited rule and workload.
+	history map[string][]time.Time
+}
+
+func New(f *File) *Engine {
+	return &Engine{
+		rules:   f.Rules,
+		now:     time.Now,
+		history: make(map[string][]time.Time),
+	}
+}
+
+// Check returns a *service.PolicyViolationError for the first rule the action
+// violates, or a *service.ApprovalRequiredError when the action is otherwise
+// allowed but a matching rule requires approval. Allowed actions are counted
+// against rate limits unless they are dry runs.
+func (e *Engine) Check(ctx context.Context, action *entity.Action) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	var limited []string
+	var approvalRule *Rule
+	for _, rule := range e.rules {
+		if !rule.matches(action, now) {
+			continue
+		}
+		if rule.Deny {
+			return violation(rule, "action %s is not allowed", action.Kind)
+		}
+		if action.Kind == entity.ActionScale {
+			if rule.MinReplicas != nil && action.TargetReplicas < *rule.MinReplicas {
+				return violation(rule, "replicas %d is below the minimum of %d", action.TargetReplicas, *rule.MinReplicas)
+			}
+			if rule.MaxReplicas != nil && action.TargetReplicas > *rule.MaxReplicas {
+				return violation(rule, "replicas %d is above the maximum of %d", action.TargetReplicas, *rule.MaxReplicas)
+			}
+		}
+		if action.Kind == entity.ActionAutoscale {
+			if rule.MinReplicas != nil && action.MinReplicas < *rule.MinReplicas {
+				return violation(rule, "minReplicas %d is below the minimum of %d", action.MinReplicas, *rule.MinReplicas)
+			}
+			if rule.MaxReplicas != nil && action.MaxReplicas > *rule.MaxReplicas {
+				return violation(rule, "maxReplicas %d is above the maximum of %d", action.MaxReplicas, *rule.MaxReplicas)
+			}
+		}
+		if rule.RateLimit != nil {
+			key := rateKey(rule, action)
+			if e.count(key, now.Add(-rule.RateLimit.Per)) >= rule.RateLimit.Max {
+				return violation(rule, "limit of %d %s actions per %s reached", rule.RateLimit.Max, action.Kind, rule.RateLimit.Per)
+			}
+			limited = append(limited, key)
+		}
+		if rule.RequireApproval && approvalRule == nil {
+			approvalRule = rule
+		}
+	}
+
+	// Dry runs only preview the action, so they never wait for approval.
+	if approvalRule != nil && !action.Approved && !action.DryRun {
+		return &service.ApprovalRequiredError{Rule: approvalRule.Name}
+	}
+	if !action.DryRun {
+		for _, key := range limited {
+			e.history[key] = append(e.history[key], now)
+		}
+	}
+	return nil
+}
+
+// count drops history entries older than since and returns how many remain.
+func (e *Engine) count(key string, since time.Time) int {
+	times := e.history[key]
+	i := 0
+	for i < len(times) && times[i].Before(since) {
+		i++
+	}
+	e.history[key] = times[i:]
+	return len(times) - i
+}
+
+func (r *Rule) matches(action *entity.Action, now time.Time) bool {
+	if len(r.Match.Actions) > 0 && !slices.Contains(r.Match.Actions, string(action.Kind)) {
+		return false
+	}
+	if len(r.Match.Clusters) > 0 && !slices.ContainsFunc(r.Match.Clusters, func(pattern string) bool {
+		ok, _ := path.Match(pattern, action.Cluster)
+		return ok
+	}) {
+		return false
+	}
+	if len(r.Match.Namespaces) > 0 && !slices.ContainsFunc(r.Match.Namespaces, func(pattern string) bool {
+		ok, _ := path.Match(pattern, action.Namespace)
+		return ok
+	}) {
+		return false
+	}
+	if r.Match.TargetReplicas != nil &&
+		(action.Kind != entity.ActionScale || !r.Match.TargetReplicas.contains(action.TargetReplicas)) {
+		return false
+	}
+	for key, value := range r.Match.Labels {
+		if action.Labels[key] != value {
+			return false
+		}
+	}
+	return r.Window == nil || r.Window.contains(now)
+}
+
+func (w *Window) contains(now time.Time) bool {
+	now = now.In(w.location)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, w.location)
+	offset := now.Sub(midnight)
+	day := now.Weekday()
+
+	if w.from <= w.to {
+		return w.onDay(day) && offset >= w.from && offset < w.to
+	}
+	// The window spans midnight, e.g. 22:00-06:00: the early part belongs
+	// to the window that started the day before.
+	if offset >= w.from {
+		return w.onDay(day)
+	}
+	return offset < w.to && w.onDay((day+6)%7)
+}
+
+func (w *Window) onDay(day time.Weekday) bool {
+	return len(w.days) == 0 || w.days[day]
+}
+
+func rateKey(rule *Rule, action *entity.Action) string {
+	target := action.Owner
+	if target == "" {
+		target = action.Name
+	}
+	return rule.Name + "/" + action.Cluster + "/" + action.Namespace + "/" + target
+}
+
+func violation(rule *Rule, format string, args ...any) error {
+	return &service.PolicyViolationError{Rule: rule.Name, Reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,268 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+)
+
+func newEngine(t *testing.T, policy string) *Engine {
+	t.Helper()
+	var f File
+	if err := yaml.UnmarshalStrict([]byte(policy), &f); err != nil {
+		t.Fatalf("failed to parse policy: %v", err)
+	}
+	if err := f.validate(); err != nil {
+		t.Fatalf("invalid policy: %v", err)
+	}
+	return New(&f)
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		window string
+		now    string
+		want   bool
+	}{
+		{"inside", `{from: "09:00", to: "17:00"}`, "2026-10-19T12:00:00Z", true},
+		{"start is inclusive", `{from: "09:00", to: "17:00"}`, "2026-10-19T09:00:00Z", true},
+		{"end is exclusive", `{from: "09:00", to: "17:00"}`, "2026-10-19T17:00:00Z", false},
+		{"other day", `{days: [mon], from: "09:00", to: "17:00"}`, "2026-10-20T12:00:00Z", false},
+		{"full day names", `{days: [Monday]}`, "2026-10-19T23:59:00Z", true},
+		{"timezone", `{from: "09:00", to: "17:00", timezone: Europe/Berlin}`, "2026-10-19T07:30:00Z", true},
+		{"timezone shifts the end", `{from: "09:00", to: "17:00", timezone: Europe/Berlin}`, "2026-10-19T15:30:00Z", false},
+		{"timezone shifts the day", `{days: [tue], timezone: Europe/Berlin}`, "2026-10-19T22:30:00Z", true},
+
+		// A Friday night freeze from 22:00 until Saturday 06:00.
+		{"overnight before start", `{days: [fri], from: "22:00", to: "06:00"}`, "2026-10-16T21:59:00Z", false},
+		{"overnight evening", `{days: [fri], from: "22:00", to: "06:00"}`, "2026-10-16T23:00:00Z", true},
+		{"overnight morning after", `{days: [fri], from: "22:00", to: "06:00"}`, "2026-10-17T05:59:00Z", true},
+		{"overnight end is exclusive", `{days: [fri], from: "22:00", to: "06:00"}`, "2026-10-17T06:00:00Z", false},
+		{"overnight morning of the day", `{days: [fri], from: "22:00", to: "06:00"}`, "2026-10-16T05:00:00Z", false},
+		{"overnight evening of the next day", `{days: [fri], from: "22:00", to: "06:00"}`, "2026-10-17T23:00:00Z", false},
+		{"overnight every day", `{from: "22:00", to: "06:00"}`, "2026-10-19T03:00:00Z", true},
+		{"overnight midday", `{from: "22:00", to: "06:00"}`, "2026-10-19T12:00:00Z", false},
+		{"overnight sunday into monday", `{days: [sun], from: "22:00", to: "06:00"}`, "2026-10-19T01:00:00Z", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w Window
+			if err := yaml.UnmarshalStrict([]byte(tt.window), &w); err != nil {
+				t.Fatalf("failed to parse window: %v", err)
+			}
+			if err := w.compile(); err != nil {
+				t.Fatalf("invalid window: %v", err)
+			}
+			now, err := time.Parse(time.RFC3339, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := w.contains(now); got != tt.want {
+				t.Errorf("contains(%s) = %t, want %t", now.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowCompileErrors(t *testing.T) {
+	for _, window := range []string{
+		`{days: [someday]}`,
+		`{days: [""]}`,
+		`{from: "25:00"}`,
+		`{to: "9am"}`,
+		`{timezone: Nowhere/Special}`,
+	} {
+		var w Window
+		if err := yaml.UnmarshalStrict([]byte(window), &w); err != nil {
+			t.Fatalf("failed to parse window %s: %v", window, err)
+		}
+		if err := w.compile(); err == nil {
+			t.Errorf("window %s compiled", window)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	const policy = `
+rules:
+  - name: restarts
+    match:
+      actions: [restart]
+    rateLimit:
+      max: 2
+      per: 1h
+  - name: no-restarts-in-kube-system
+    match:
+      actions: [restart]
+      namespaces: [kube-system]
+    deny: true
+  - name: restarts-in-payments
+    match:
+      actions: [restart]
+      namespaces: [payments]
+    requireApproval: true
+`
+	restart := func(namespace, owner string) *entity.Action {
+		return &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: namespace, Name: owner + "-0", Owner: owner}
+	}
+	type step struct {
+		after   time.Duration
+		action  *entity.Action
+		limited bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "limit per workload",
+			steps: []step{
+				{0, restart("default", "web"), false},
+				{time.Minute, restart("default", "web"), false},
+				{time.Minute, restart("default", "web"), true},
+				{time.Minute, restart("default", "api"), false},
+				{time.Minute, restart("other", "web"), false},
+			},
+		},
+		{
+			name: "old actions fall out of the period",
+			steps: []step{
+				{0, restart("default", "web"), false},
+				{30 * time.Minute, restart("default", "web"), false},
+				{29 * time.Minute, restart("default", "web"), true},
+				// The first restart is an hour old: it still counts.
+				{time.Minute, restart("default", "web"), true},
+				{time.Second, restart("default", "web"), false},
+				{time.Minute, restart("default", "web"), true},
+			},
+		},
+		{
+			name: "pods without owner are limited by name",
+			steps: []step{
+				{0, restart("default", ""), false},
+				{0, restart("default", ""), false},
+				{0, restart("default", ""), true},
+			},
+		},
+		{
+			name: "dry runs are not counted",
+			steps: []step{
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "default", Owner: "web", DryRun: true}, false},
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "default", Owner: "web", DryRun: true}, false},
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "default", Owner: "web", DryRun: true}, false},
+				{0, restart("default", "web"), false},
+				{0, restart("default", "web"), false},
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "default", Owner: "web", DryRun: true}, true},
+			},
+		},
+		{
+			name: "refused actions are not counted",
+			steps: []step{
+				{0, restart("kube-system", "dns"), false},
+				{0, restart("kube-system", "dns"), false},
+				{0, restart("kube-system", "dns"), false},
+			},
+		},
+		{
+			name: "actions waiting for approval are not counted",
+			steps: []step{
+				{0, restart("payments", "web"), false},
+				{0, restart("payments", "web"), false},
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "payments", Owner: "web", Approved: true}, false},
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "payments", Owner: "web", Approved: true}, false},
+				{0, &entity.Action{Kind: entity.ActionRestart, Cluster: "prod", Namespace: "payments", Owner: "web", Approved: true}, true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newEngine(t, policy)
+			now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+			engine.now = func() time.Time { return now }
+
+			for i, step := range tt.steps {
+				now = now.Add(step.after)
+				err := engine.Check(context.Background(), step.action)
+
+				var violation *service.PolicyViolationError
+				limited := errors.As(err, &violation) && violation.Rule == "restarts"
+				if limited != step.limited {
+					t.Errorf("step %d: err = %v, want limited %t", i, err, step.limited)
+				}
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	const policy = `
+rules:
+  - name: weekend-freeze
+    match:
+      clusters: [prod-*]
+    window:
+      days: [sat, sun]
+    deny: true
+  - name: scale-bounds
+    match:
+      actions: [scale, autoscale]
+    minReplicas: 1
+    maxReplicas: 10
+  - name: big-scale-ups
+    match:
+      actions: [scale]
+      targetReplicas:
+        min: 6
+    requireApproval: true
+`
+	monday := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		now    time.Time
+		action entity.Action
+		rule   string
+		wait   bool
+	}{
+		{"allowed", monday, entity.Action{Kind: entity.ActionRestart, Cluster: "prod-eu"}, "", false},
+		{"frozen", saturday, entity.Action{Kind: entity.ActionRestart, Cluster: "prod-eu"}, "weekend-freeze", false},
+		{"other cluster on weekend", saturday, entity.Action{Kind: entity.ActionRestart, Cluster: "staging"}, "", false},
+		{"scale below minimum", monday, entity.Action{Kind: entity.ActionScale, TargetReplicas: 0}, "scale-bounds", false},
+		{"scale above maximum", monday, entity.Action{Kind: entity.ActionScale, TargetReplicas: 11}, "scale-bounds", false},
+		{"small scale", monday, entity.Action{Kind: entity.ActionScale, TargetReplicas: 5}, "", false},
+		{"big scale", monday, entity.Action{Kind: entity.ActionScale, TargetReplicas: 6}, "", true},
+		{"big scale approved", monday, entity.Action{Kind: entity.ActionScale, TargetReplicas: 6, Approved: true}, "", false},
+		{"big scale dry run", monday, entity.Action{Kind: entity.ActionScale, TargetReplicas: 6, DryRun: true}, "", false},
+		{"autoscaler limits", monday, entity.Action{Kind: entity.ActionAutoscale, MinReplicas: 1, MaxReplicas: 10}, "", false},
+		{"autoscaler above maximum", monday, entity.Action{Kind: entity.ActionAutoscale, MinReplicas: 2, MaxReplicas: 20}, "scale-bounds", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newEngine(t, policy)
+			engine.now = func() time.Time { return tt.now }
+			err := engine.Check(context.Background(), &tt.action)
+
+			var violation *service.PolicyViolationError
+			var approval *service.ApprovalRequiredError
+			switch {
+			case tt.rule != "":
+				if !errors.As(err, &violation) || violation.Rule != tt.rule {
+					t.Errorf("err = %v, want violation of %s", err, tt.rule)
+				}
+			case tt.wait:
+				if !errors.As(err, &approval) {
+					t.Errorf("err = %v, want approval required", err)
+				}
+			case err != nil:
+				t.Errorf("err = %v, want nil", err)
+			}
+		})
+	}
+}
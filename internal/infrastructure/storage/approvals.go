@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+)
+
+// Approvals keeps approvals in memory and, when a file name is given,
+// persists them as JSON so pending approvals survive restarts. Approvals are
+// dropped once they expired more than the retention ago, decided or not.
+type Approvals struct {
+	filename  string
+	retention time.Duration
+
+	mu        sync.RWMutex
+	approvals map[string]*entity.Approval
+}
+
+func NewApprovals(filename string, retention time.Duration) (*Approvals, error) {
+	s := &Approvals{
+		filename:  filename,
+		retention: retention,
+		approvals: make(map[string]*entity.Approval),
+	}
+	if filename == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approvals: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.approvals); err != nil {
+		return nil, fmt.Errorf("failed to parse approvals: %w", err)
+	}
+	if s.prune(time.Now()) {
+		if err := s.flush(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Approvals) Save(ctx context.Context, approval *entity.Approval) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *approval
+	s.approvals[approval.ID] = &stored
+	s.prune(time.Now())
+	return s.flush()
+}
+
+func (s *Approvals) Get(ctx context.Context, id string) (*entity.Approval, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	approval, ok := s.approvals[id]
+	if !ok {
+		return nil, service.ErrApprovalNotFound
+	}
+	found := *approval
+	return &found, nil
+}
+
+// prune drops the approvals that expired more than the retention ago and
+// reports whether there were any. Must be called with mu held.
+func (s *Approvals) prune(now time.Time) bool {
+	pruned := false
+	for id, approval := range s.approvals {
+		if now.Sub(approval.ExpiresAt) > s.retention {
+			delete(s.approvals, id)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// flush atomically rewrites the approvals file. Must be called with mu held.
+func (s *Approvals) flush() error {
+	if s.filename == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.approvals, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal approvals: %w", err)
+	}
+	return writeFile(s.filename, data)
+}
+
+// writeFile replaces filename with data via a temporary file and rename, so
+// readers never observe a partially written file.
+func writeFile(filename string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", filename, err)
+	}
+	return nil
+}
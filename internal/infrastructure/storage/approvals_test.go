@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+	"github.com/inviewteam/fenrir.executor/internal/domain/service"
+)
+
+func TestApprovalsRetention(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		status    entity.ApprovalStatus
+		retention time.Duration
+		kept      bool
+	}{
+		{"pending", now.Add(time.Hour), entity.ApprovalPending, time.Hour, true},
+		{"expired within the retention", now.Add(-30 * time.Minute), entity.ApprovalPending, time.Hour, true},
+		{"expired past the retention", now.Add(-2 * time.Hour), entity.ApprovalPending, time.Hour, false},
+		{"decided past the retention", now.Add(-2 * time.Hour), entity.ApprovalExecuted, time.Hour, false},
+		{"decided within the retention", now.Add(-30 * time.Minute), entity.ApprovalRejected, time.Hour, true},
+		{"no retention", now.Add(-time.Minute), entity.ApprovalExpired, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, err := NewApprovals("", tt.retention)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Save(ctx, &entity.Approval{ID: "a1", Status: tt.status, ExpiresAt: tt.expiresAt}); err != nil {
+				t.Fatal(err)
+			}
+			// Pruning happens on every save, including of other approvals.
+			if err := s.Save(ctx, &entity.Approval{ID: "a2", Status: entity.ApprovalPending, ExpiresAt: now.Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = s.Get(ctx, "a1")
+			if kept := err == nil; kept != tt.kept {
+				t.Errorf("kept = %t, want %t (err = %v)", kept, tt.kept, err)
+			}
+			if err != nil && !errors.Is(err, service.ErrApprovalNotFound) {
+				t.Errorf("err = %v, want %v", err, service.ErrApprovalNotFound)
+			}
+		})
+	}
+}
+
+func TestApprovalsPersistence(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "approvals.json")
+	now := time.Now().Truncate(time.Second)
+
+	s, err := NewApprovals(filename, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	approval := &entity.Approval{
+		ID:          "a1",
+		Action:      &entity.Action{Kind: entity.ActionScale, Cluster: "prod", Namespace: "default", Name: "web", TargetReplicas: 3},
+		Rule:        "big-scale-ups",
+		Status:      entity.ApprovalPending,
+		RequestedBy: "alice",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	if err := s.Save(ctx, approval); err != nil {
+		t.Fatal(err)
+	}
+	// The store keeps a copy, not the caller's approval.
+	approval.Status = entity.ApprovalRejected
+	if stored, _ := s.Get(ctx, "a1"); stored.Status != entity.ApprovalPending {
+		t.Errorf("status = %s, want %s", stored.Status, entity.ApprovalPending)
+	}
+
+	reloaded, err := NewApprovals(filename, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := reloaded.Get(ctx, "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != entity.ApprovalPending || stored.RequestedBy != "alice" || stored.Action.TargetReplicas != 3 ||
+		!stored.ExpiresAt.Equal(approval.ExpiresAt) {
+		t.Errorf("reloaded approval = %+v", stored)
+	}
+
+	// Approvals that went stale while the executor was down are dropped
+	// from the file when it is loaded.
+	reloaded.approvals["a2"] = &entity.Approval{ID: "a2", ExpiresAt: now.Add(-time.Hour)}
+	if err := reloaded.flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewApprovals(filename, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err = NewApprovals(filename, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Get(ctx, "a2"); !errors.Is(err, service.ErrApprovalNotFound) {
+		t.Errorf("stale approval was kept: %v", err)
+	}
+	if _, err := reloaded.Get(ctx, "a1"); err != nil {
+		t.Errorf("pending approval was dropped: %v", err)
+	}
+}
+
+func TestApprovalsBadFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "approvals.json")
+	if err := os.WriteFile(filename, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewApprovals(filename, time.Hour); err == nil {
+		t.Error("corrupt approvals file was loaded")
+	}
+}
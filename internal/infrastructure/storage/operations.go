@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/inviewteam/fenrir.executor/internal/domain/entity"
+)
+
+// Operations keeps operations in memory and, when a file name is given,
+// persists them as JSON. Finished operations are dropped after the ttl.
+type Operations struct {
+	filename string
+	ttl      time.Duration
+
+	mu         sync.Mutex
+	operations map[string]*entity.Operation
+}
+
+// NewOperations loads the operations persisted in filename. Operations still
+// running there were cut short when the previous process died without a
+// graceful shutdown and are marked as interrupted.
+func NewOperations(filename string, ttl time.Duration) (*Operations, error) {
+	s := &Operations{
+		filename:   filename,
+		ttl:        ttl,
+		operations: make(map[string]*entity.Operation),
+	}
+	if filename == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operations: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.operations); err != nil {
+		return nil, fmt.Errorf("failed to parse operations: %w", err)
+	}
+	changed := s.prune(time.Now())
+	for _, operation := range s.operations {
+		if operation.Status == entity.OperationRunning {
+			operation.Status = entity.OperationInterrupted
+			operation.FinishedAt = time.Now()
+			operation.Error = "interrupted by executor crash"
+			changed = true
+		}
+	}
+	if changed {
+		if err := s.flush(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *Operations) Save(ctx context.Context, operation *entity.Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *operation
+	s.operations[operation.ID] = &stored
+	s.prune(time.Now())
+	return s.flush()
+}
+
+// prune drops the operations finished more than ttl ago and reports whether
+// there were any. Must be called with mu held.
+func (s *Operations) prune(now time.Time) bool {
+	pruned := false
+	for id, operation := range s.operations {
+		if operation.Status != entity.OperationRunning && now.Sub(operation.FinishedAt) > s.ttl {
+			delete(s.operations, id)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// flush atomically rewrites the operations file. Must be called with mu held.
+func (s *Operations) flush() error {
+	if s.filename == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.operations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal operations: %w", err)
+	}
+	return writeFile(s.filename, data)
+}